@@ -0,0 +1,203 @@
+package beosin
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// utxoParams are the address parameters of a UTXO chain
+type utxoParams struct {
+	versions []byte
+	hrp      string
+}
+
+// utxoChains holds the address parameters per UTXO chain ID
+var utxoChains = map[string]utxoParams{
+	ChainBTC: {versions: []byte{0x00, 0x05}, hrp: "bc"},
+	ChainLTC: {versions: []byte{0x30, 0x32, 0x05}, hrp: "ltc"},
+}
+
+// ValidateAddress checks an address against the format of the chain and returns its
+// canonical form. The chain may be given by ID or alias; addresses on chains missing
+// from the registry are returned unchanged.
+func ValidateAddress(chainID, address string) (string, error) {
+	chain, ok := LookupChain(chainID)
+	if !ok {
+		return address, nil
+	}
+	normalized, reason := normalizeAddress(chain, strings.TrimSpace(address))
+	if reason != "" {
+		return "", &ValidationError{Field: "address", Value: address, Reason: reason}
+	}
+	return normalized, nil
+}
+
+// normalizeAddress returns the canonical form of an address, or the reason it is invalid
+func normalizeAddress(chain Chain, address string) (string, string) {
+	if address == "" {
+		return "", "must not be empty"
+	}
+
+	switch chain.Family {
+	case FamilyEVM:
+		return normalizeEVMAddress(address)
+	case FamilyUTXO:
+		params, ok := utxoChains[chain.ID]
+		if !ok {
+			params = utxoChains[ChainBTC]
+		}
+		return normalizeUTXOAddress(address, params)
+	case FamilyTron:
+		return normalizeTronAddress(address)
+	case FamilySolana:
+		if data, ok := bitcoinAlphabet.decode(address); !ok || len(data) != 32 {
+			return "", "must be a base58 encoded 32-byte public key"
+		}
+		return address, ""
+	case FamilyTON:
+		return normalizeTONAddress(address)
+	case FamilyXRP:
+		payload, ok := rippleAlphabet.decodeCheck(address)
+		if !ok || len(payload) != 21 || payload[0] != 0x00 {
+			return "", "must be a classic XRP address with a valid checksum"
+		}
+		return address, ""
+	case FamilyAptos, FamilySui:
+		return normalizeMoveAddress(address)
+	}
+	return address, ""
+}
+
+// normalizeEVMAddress validates a hex address and returns its EIP-55 checksum form.
+// Mixed-case addresses must carry a valid checksum.
+func normalizeEVMAddress(address string) (string, string) {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") && !strings.HasPrefix(address, "0X") || !isHex(address[2:]) {
+		return "", "must be 0x followed by 40 hex digits"
+	}
+
+	digits := address[2:]
+	checksummed := eip55(digits)
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && digits != checksummed[2:] {
+		return "", "invalid EIP-55 checksum"
+	}
+	return checksummed, ""
+}
+
+// eip55 returns the EIP-55 checksum form of 40 hex digits
+func eip55(digits string) string {
+	lower := strings.ToLower(digits)
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	sum := hash.Sum(nil)
+
+	out := []byte("0x" + lower)
+	for i := 0; i < len(lower); i++ {
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if lower[i] >= 'a' && nibble >= 8 {
+			out[i+2] = lower[i] - 'a' + 'A'
+		}
+	}
+	return string(out)
+}
+
+// normalizeUTXOAddress validates a base58check or segwit address of a Bitcoin-derived chain
+func normalizeUTXOAddress(address string, params utxoParams) (string, string) {
+	if payload, ok := bitcoinAlphabet.decodeCheck(address); ok {
+		if len(payload) != 21 || !slices.Contains(params.versions, payload[0]) {
+			return "", "unknown base58 address version"
+		}
+		return address, ""
+	}
+
+	hrp, data, constant, ok := bech32Decode(address)
+	if !ok {
+		return "", "must be a base58check or bech32 address with a valid checksum"
+	}
+	if hrp != params.hrp {
+		return "", "unexpected bech32 prefix " + strconv.Quote(hrp)
+	}
+	if len(data) == 0 || data[0] > 16 {
+		return "", "invalid witness version"
+	}
+	program, ok := convertBits(data[1:], 5, 8)
+	if !ok || len(program) < 2 || len(program) > 40 {
+		return "", "invalid witness program"
+	}
+
+	version := data[0]
+	switch {
+	case version == 0 && constant != bech32Const, version > 0 && constant != bech32mConst:
+		return "", "wrong bech32 variant for witness version " + strconv.Itoa(int(version))
+	case version == 0 && len(program) != 20 && len(program) != 32:
+		return "", "invalid witness program length"
+	}
+	return strings.ToLower(address), ""
+}
+
+// normalizeTronAddress validates a base58check Tron address. Hex addresses
+// (41 followed by 40 hex digits) are converted to base58check.
+func normalizeTronAddress(address string) (string, string) {
+	if len(address) == 42 && strings.HasPrefix(address, "41") && isHex(address) {
+		payload, _ := hex.DecodeString(address)
+		return bitcoinAlphabet.encodeCheck(payload), ""
+	}
+
+	payload, ok := bitcoinAlphabet.decodeCheck(address)
+	if !ok || len(payload) != 21 || payload[0] != 0x41 {
+		return "", "must be a base58check address starting with T"
+	}
+	return address, ""
+}
+
+// normalizeTONAddress validates a raw (workchain:hex) or user-friendly base64 TON address
+func normalizeTONAddress(address string) (string, string) {
+	if workchain, account, ok := strings.Cut(address, ":"); ok {
+		if workchain != "0" && workchain != "-1" {
+			return "", "unknown workchain " + strconv.Quote(workchain)
+		}
+		if len(account) != 64 || !isHex(account) {
+			return "", "raw account ID must be 64 hex digits"
+		}
+		return workchain + ":" + strings.ToLower(account), ""
+	}
+
+	if len(address) != 48 {
+		return "", "must be a raw or 48-character user-friendly address"
+	}
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(address, "-_") {
+		encoding = base64.URLEncoding
+	}
+	data, err := encoding.DecodeString(address)
+	if err != nil || len(data) != 36 {
+		return "", "invalid base64 encoding"
+	}
+	if tag := data[0] &^ 0x80; tag != 0x11 && tag != 0x51 {
+		return "", "unknown address tag"
+	}
+	if data[1] != 0x00 && data[1] != 0xff {
+		return "", "unknown workchain"
+	}
+	if crc16XModem(data[:34]) != binary.BigEndian.Uint16(data[34:]) {
+		return "", "invalid checksum"
+	}
+	return address, ""
+}
+
+// normalizeMoveAddress validates an Aptos or Sui account address and pads it to 64 hex digits
+func normalizeMoveAddress(address string) (string, string) {
+	digits, ok := strings.CutPrefix(strings.ToLower(address), "0x")
+	if !ok || len(digits) == 0 || len(digits) > 64 || !isHex(digits) {
+		return "", "must be 0x followed by up to 64 hex digits"
+	}
+	return "0x" + strings.Repeat("0", 64-len(digits)) + digits, ""
+}
@@ -0,0 +1,108 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestValidateAddress tests address validation and normalization for every chain family
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		chain   string
+		address string
+		want    string
+		valid   bool
+	}{
+		{ChainETH, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{ChainBSC, " 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed ", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{ChainETH, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", "", false},
+		{ChainETH, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", "", false},
+		{ChainBTC, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+		{ChainBTC, "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", true},
+		{ChainBTC, "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", true},
+		{ChainBTC, "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", true},
+		{ChainBTC, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", "", false},
+		{ChainLTC, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "", false},
+		{ChainTron, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", true},
+		{ChainTron, "41a614f803b6fd780986a42c78ec9c7f77e6ded13c", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", true},
+		{ChainTron, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "", false},
+		{ChainSolana, "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", true},
+		{ChainSolana, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "", false},
+		{ChainTON, "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2", "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2", true},
+		{ChainTON, "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q3", "", false},
+		{ChainTON, "0:ED16913070500471A2B8D8BCB94A6FC1A0FCBFB3C4B97C9D1CA3F8A0E7FE27A", "", false},
+		{ChainXRP, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", true},
+		{ChainXRP, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTj", "", false},
+		{ChainAptos, "0x1", "0x0000000000000000000000000000000000000000000000000000000000000001", true},
+		{ChainSui, "sui-address", "", false},
+		{"unknown-chain", "anything", "anything", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ValidateAddress(tt.chain, tt.address)
+		if tt.valid && (err != nil || got != tt.want) {
+			t.Errorf("ValidateAddress(%s, %s) = %q, %v; want %q", tt.chain, tt.address, got, err, tt.want)
+		}
+		if !tt.valid && !errors.Is(err, ErrAddressInvalid) {
+			t.Errorf("ValidateAddress(%s, %s) = %q, %v; want an address error", tt.chain, tt.address, got, err)
+		}
+	}
+}
+
+// TestRequestValidation tests that invalid requests are rejected without an HTTP call and
+// that valid chains and addresses are sent in canonical form without modifying the caller's request
+func TestRequestValidation(t *testing.T) {
+	var sent, chainIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.URL.Query().Get("address"))
+		chainIDs = append(chainIDs, r.URL.Query().Get("chainId"))
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"isVasp":false}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithRequestValidation(true))
+	ctx := context.Background()
+
+	_, err := client.VASPQuery(ctx, &VASPRequest{ChainID: ChainETH, Address: "0x1"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "address" {
+		t.Fatalf("Expected an address validation error, got %v", err)
+	}
+
+	req := &VASPRequest{ChainID: "Ethereum", Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}
+	if _, err := client.VASPQuery(ctx, req); err != nil {
+		t.Fatalf("VASPQuery failed: %v", err)
+	}
+	if len(sent) != 1 || sent[0] != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" {
+		t.Errorf("Expected one request with the checksummed address, got %v", sent)
+	}
+	if len(chainIDs) != 1 || chainIDs[0] != ChainETH {
+		t.Errorf("Expected the chain alias to be sent as %s, got %v", ChainETH, chainIDs)
+	}
+	if req.Address != "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed" || req.ChainID != "Ethereum" {
+		t.Errorf("Expected the caller's request to be unchanged, got %+v", req)
+	}
+
+	// Calls without a request are not validated
+	if _, err := client.GetAccountBalance(ctx); err != nil {
+		t.Errorf("GetAccountBalance failed: %v", err)
+	}
+
+	if err := (&BlackScreeningRequest{Platform: "tron", Address: "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"}).Validate(); err != nil {
+		t.Errorf("Expected a valid Tron address, got %v", err)
+	}
+	screening := &BlackScreeningRequest{Platform: "BNB", Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}
+	if err := screening.Normalize(); err != nil || screening.Platform != "bsc" {
+		t.Errorf("Expected the platform alias to be rewritten to bsc, got %q, %v", screening.Platform, err)
+	}
+
+	// Error messages mask the rejected value like debug logs do
+	bad := "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeZ"
+	if _, err := ValidateAddress(ChainETH, bad); err == nil || strings.Contains(err.Error(), bad) {
+		t.Errorf("Expected a masked validation error, got %v", err)
+	}
+}
@@ -6,14 +6,13 @@ import (
 )
 
 const (
-	endpointAccountBalance = "/api/v1/package/info"
+	// EndpointAccountBalance is the endpoint for account balance queries
+	EndpointAccountBalance = "/api/v1/package/info"
 )
 
 // GetAccountBalance queries the account balance
 func (c *client) GetAccountBalance(ctx context.Context) (*AccountBalanceResponse, error) {
-	var resp AccountBalanceResponse
-	if err := c.doRequest(ctx, endpointAccountBalance, url.Values{}, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
+	return invoke[AccountBalanceResponse](ctx, c, OperationGetAccountBalance, EndpointAccountBalance, (*struct{})(nil), func(*struct{}) url.Values {
+		return url.Values{}
+	})
 }
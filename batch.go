@@ -0,0 +1,100 @@
+package beosin
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default number of requests a batch runs at once
+const DefaultBatchConcurrency = 4
+
+// Result is the outcome of one request of a batch or stream
+type Result[Req, Resp any] struct {
+	// Index is the position of the request in the input
+	Index int
+
+	// Request is the request that was sent
+	Request Req
+
+	// Response is the response, if the request succeeded
+	Response Resp
+
+	// Err is the error, if the request failed
+	Err error
+}
+
+// BatchProgress reports how far a batch has progressed
+type BatchProgress struct {
+	// Total is the number of requests in the batch
+	Total int
+
+	// Completed is the number of requests that have finished, successfully or not
+	Completed int
+
+	// Failed is the number of requests that have failed
+	Failed int
+}
+
+// BatchOptions configures a batch run
+type BatchOptions struct {
+	// Concurrency is the maximum number of requests in flight (defaults to DefaultBatchConcurrency)
+	Concurrency int
+
+	// OnProgress is called after each request has finished; calls are never concurrent
+	OnProgress func(BatchProgress)
+}
+
+// Batch runs fn for every request with bounded concurrency and returns the results in input order.
+// A failed request does not stop the batch; its error is reported in its result. Requests that
+// have not started when the context is done fail with the context error. Any rate limit configured
+// on the client applies, so fn is typically a Client method value:
+//
+//	results := beosin.Batch(ctx, reqs, client.BlackAddressScreening, beosin.BatchOptions{Concurrency: 8})
+func Batch[Req, Resp any](ctx context.Context, reqs []Req, fn func(context.Context, Req) (Resp, error), opts BatchOptions) []Result[Req, Resp] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]Result[Req, Resp], len(reqs))
+	progress := BatchProgress{Total: len(reqs)}
+	var mu sync.Mutex
+	report := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress.Completed++
+		if err != nil {
+			progress.Failed++
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(progress)
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(concurrency, len(reqs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				result := Result[Req, Resp]{Index: index, Request: reqs[index]}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else {
+					result.Response, result.Err = fn(ctx, reqs[index])
+				}
+				results[index] = result
+				report(result.Err)
+			}
+		}()
+	}
+
+	for index := range reqs {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,61 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// TestBatch tests ordering, partial failure, concurrency and progress reporting
+func TestBatch(t *testing.T) {
+	reqs := []*BlackScreeningRequest{
+		{Platform: "eth", Address: "0x1"},
+		{Platform: "eth", Address: "bad"},
+		{Platform: "eth", Address: "0x3"},
+		{Platform: "eth", Address: "0x4"},
+	}
+
+	var inFlight, maxInFlight int32
+	screen := func(ctx context.Context, req *BlackScreeningRequest) (*BlackScreeningResponse, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		if req.Address == "bad" {
+			return nil, ErrAddressInvalid
+		}
+		return &BlackScreeningResponse{Data: &BlackScreeningData{Sanction: req.Address == "0x3"}}, nil
+	}
+
+	var last BatchProgress
+	results := Batch(context.Background(), reqs, screen, BatchOptions{
+		Concurrency: 2,
+		OnProgress:  func(p BatchProgress) { last = p },
+	})
+
+	if len(results) != len(reqs) {
+		t.Fatalf("Expected %d results, got %d", len(reqs), len(results))
+	}
+	for i, result := range results {
+		if result.Index != i || result.Request != reqs[i] {
+			t.Errorf("Result %d out of order: %+v", i, result)
+		}
+	}
+	if !errors.Is(results[1].Err, ErrAddressInvalid) {
+		t.Errorf("Expected address error for item 1, got %v", results[1].Err)
+	}
+	if results[2].Err != nil || !results[2].Response.Data.Sanction {
+		t.Errorf("Unexpected result for item 2: %+v", results[2])
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+	if last != (BatchProgress{Total: 4, Completed: 4, Failed: 1}) {
+		t.Errorf("Unexpected final progress: %+v", last)
+	}
+}
@@ -0,0 +1,172 @@
+// Package beosinotel provides OpenTelemetry tracing for the Beosin client.
+//
+// Register the interceptor when creating the client:
+//
+//	client := beosin.NewClient(appID, appSecret,
+//		beosin.WithInterceptors(beosinotel.Interceptor()),
+//	)
+package beosinotel
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer used by this package
+const instrumentationName = "github.com/ABT-Tech-Limited/beosin-go/beosinotel"
+
+// Attribute keys recorded on Beosin spans
+const (
+	AttrOperation   = attribute.Key("beosin.operation")
+	AttrEndpoint    = attribute.Key("beosin.endpoint")
+	AttrChainID     = attribute.Key("beosin.chain_id")
+	AttrAddressHash = attribute.Key("beosin.address_hash")
+	AttrTxHashHash  = attribute.Key("beosin.tx_hash_hash")
+	AttrAPICode     = attribute.Key("beosin.api_code")
+	AttrRiskLevel   = attribute.Key("beosin.risk_level")
+	AttrRetryCount  = attribute.Key("beosin.retry_count")
+	AttrHTTPStatus  = attribute.Key("http.response.status_code")
+)
+
+// config holds the interceptor configuration
+type config struct {
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+	hashKey        []byte
+}
+
+// Option configures the tracing interceptor
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider (defaults to the global provider)
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithPropagator sets the propagator used to inject trace context into outbound requests
+// (defaults to the global propagator)
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// WithHashKey records addresses and transaction hashes as HMAC-SHA256 digests with the
+// given secret key, so spans of the same address can be correlated. Addresses are public,
+// so the key must stay secret for the digests not to be reversible.
+func WithHashKey(key []byte) Option {
+	return func(c *config) {
+		c.hashKey = key
+	}
+}
+
+// Interceptor returns a beosin.Interceptor that records a span for every Client call.
+// Addresses and transaction hashes are never recorded in plain text, and are only
+// recorded as keyed digests when WithHashKey is set.
+func Interceptor(opts ...Option) beosin.Interceptor {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.propagator == nil {
+		cfg.propagator = otel.GetTextMapPropagator()
+	}
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+
+	return func(ctx context.Context, info *beosin.CallInfo, req interface{}, next beosin.Handler) (interface{}, error) {
+		ctx, span := tracer.Start(ctx, "beosin."+info.Operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(requestAttributes(info, req, cfg.hashKey)...),
+		)
+		defer span.End()
+
+		cfg.propagator.Inject(ctx, propagation.HeaderCarrier(info.Header))
+
+		resp, err := next(ctx, req)
+
+		attrs := []attribute.KeyValue{AttrRetryCount.Int(max(info.Attempts-1, 0))}
+		if info.StatusCode != 0 {
+			attrs = append(attrs, AttrHTTPStatus.Int(info.StatusCode))
+		}
+		if info.Code != 0 {
+			attrs = append(attrs, AttrAPICode.Int(info.Code))
+		}
+		if info.RiskLevel != "" {
+			attrs = append(attrs, AttrRiskLevel.String(string(info.RiskLevel)))
+		}
+		span.SetAttributes(attrs...)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return resp, err
+	}
+}
+
+// requestAttributes returns the span attributes describing a request. Address and hash
+// digests are only added with a hash key.
+func requestAttributes(info *beosin.CallInfo, req interface{}, key []byte) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		AttrOperation.String(info.Operation),
+		AttrEndpoint.String(info.Endpoint),
+	}
+	if info.ChainID != "" {
+		attrs = append(attrs, AttrChainID.String(info.ChainID))
+	}
+
+	if len(key) == 0 {
+		return attrs
+	}
+	switch r := req.(type) {
+	case *beosin.DepositRequest:
+		attrs = append(attrs, AttrTxHashHash.String(digest(key, r.Hash)))
+	case *beosin.WithdrawalRequest:
+		attrs = append(attrs, AttrTxHashHash.String(digest(key, r.Hash)))
+	case *beosin.AddressRiskRequest:
+		attrs = append(attrs, AttrAddressHash.String(digest(key, r.Address)))
+	case *beosin.MaliciousAddressRequest:
+		attrs = append(attrs, AttrAddressHash.String(digest(key, r.Address)))
+	case *beosin.VASPRequest:
+		attrs = append(attrs, AttrAddressHash.String(digest(key, r.Address)))
+	case *beosin.BlackScreeningRequest:
+		attrs = append(attrs, AttrAddressHash.String(digest(key, r.Address)))
+	}
+	return attrs
+}
+
+// digest returns a hex-encoded HMAC-SHA256 digest of a normalized value
+func digest(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(normalize(value)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalize trims a value and lowercases hex values, whose case carries no meaning
+// (e.g. EIP-55 checksums), so case variants produce the same digest
+func normalize(value string) string {
+	value = strings.TrimSpace(value)
+	digits := value
+	if len(digits) > 2 && (digits[:2] == "0x" || digits[:2] == "0X") {
+		digits = digits[2:]
+	}
+	if digits == "" || strings.Trim(digits, "0123456789abcdefABCDEF") != "" {
+		return value
+	}
+	return strings.ToLower(value)
+}
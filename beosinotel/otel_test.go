@@ -0,0 +1,110 @@
+package beosinotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// testKey is the HMAC key used for address digests in tests
+var testKey = []byte("test-key")
+
+// TestInterceptor tests that a span is recorded and trace context is propagated
+func TestInterceptor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") == "" {
+			t.Error("Expected traceparent header on outbound request")
+		}
+		fmt.Fprint(w, `{"code":200,"msg":"success","data":{"score":90,"riskLevel":"Severe"}}`)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := beosin.NewClient("id", "secret",
+		beosin.WithBaseURL(server.URL),
+		beosin.WithInterceptors(Interceptor(
+			WithTracerProvider(provider),
+			WithPropagator(propagation.TraceContext{}),
+			WithHashKey(testKey),
+		)),
+	)
+
+	address := "0x901bb9583b24d97e995513c6778dc6888ab6870e"
+	_, err := client.V4EOAAddressRiskAssessment(context.Background(), &beosin.AddressRiskRequest{
+		ChainID: beosin.ChainETH,
+		Address: address,
+	})
+	if err != nil {
+		t.Fatalf("V4EOAAddressRiskAssessment failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "beosin.V4EOAAddressRiskAssessment" {
+		t.Errorf("Unexpected span name %s", span.Name())
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+		if kv.Value.AsString() == address {
+			t.Errorf("Address recorded in plain text as %s", kv.Key)
+		}
+	}
+	if attrs[AttrChainID].AsString() != beosin.ChainETH {
+		t.Errorf("Unexpected chain ID %v", attrs[AttrChainID])
+	}
+	if attrs[AttrRiskLevel].AsString() != string(beosin.RiskLevelSevere) {
+		t.Errorf("Unexpected risk level %v", attrs[AttrRiskLevel])
+	}
+	if attrs[AttrAPICode].AsInt64() != 200 {
+		t.Errorf("Unexpected API code %v", attrs[AttrAPICode])
+	}
+	if attrs[AttrAddressHash].AsString() != digest(testKey, address) {
+		t.Errorf("Unexpected address hash %v", attrs[AttrAddressHash])
+	}
+	if digest(testKey, "0x901BB9583b24D97E995513C6778dc6888AB6870e") != digest(testKey, address) {
+		t.Error("Expected case variants of an address to produce the same digest")
+	}
+	if digest([]byte("other"), address) == digest(testKey, address) {
+		t.Error("Expected the digest to depend on the key")
+	}
+}
+
+// TestInterceptorWithoutHashKey tests that addresses are not recorded without a hash key
+func TestInterceptorWithoutHashKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":200,"msg":"success","data":{"isVasp":false}}`)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := beosin.NewClient("id", "secret",
+		beosin.WithBaseURL(server.URL),
+		beosin.WithInterceptors(Interceptor(WithTracerProvider(provider))),
+	)
+
+	_, err := client.VASPQuery(context.Background(), &beosin.VASPRequest{ChainID: beosin.ChainETH, Address: "0x901bb9583b24d97e995513c6778dc6888ab6870e"})
+	if err != nil {
+		t.Fatalf("VASPQuery failed: %v", err)
+	}
+	for _, kv := range recorder.Ended()[0].Attributes() {
+		if kv.Key == AttrAddressHash || kv.Key == AttrTxHashHash {
+			t.Errorf("Expected no %s attribute without a hash key", kv.Key)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package beosinprom provides a Prometheus implementation of beosin.MetricsCollector.
+//
+// Register the collector with Prometheus and pass it to the client:
+//
+//	collector := beosinprom.NewCollector()
+//	prometheus.MustRegister(collector)
+//	client := beosin.NewClient(appID, appSecret, beosin.WithMetrics(collector))
+package beosinprom
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the default metric namespace
+const DefaultNamespace = "beosin"
+
+// config holds the collector configuration
+type config struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// Option configures the collector
+type Option func(*config)
+
+// WithNamespace sets the metric namespace
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithConstLabels sets labels added to every metric
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) {
+		c.constLabels = labels
+	}
+}
+
+// WithBuckets sets the latency histogram buckets in seconds
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// Collector records Beosin client metrics and exposes them to Prometheus.
+// It implements both beosin.MetricsCollector and prometheus.Collector.
+type Collector struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	cacheHit *prometheus.CounterVec
+	credits  prometheus.Gauge
+}
+
+// Verify interface compliance
+var (
+	_ beosin.MetricsCollector = (*Collector)(nil)
+	_ prometheus.Collector    = (*Collector)(nil)
+)
+
+// NewCollector creates a new Prometheus collector
+func NewCollector(opts ...Option) *Collector {
+	cfg := &config{
+		namespace: DefaultNamespace,
+		buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Name:        "requests_total",
+			Help:        "Total number of Beosin API calls by operation, endpoint, chain, API code and risk level.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"operation", "endpoint", "chain", "code", "risk_level"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   cfg.namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of Beosin API calls, including retries.",
+			ConstLabels: cfg.constLabels,
+			Buckets:     cfg.buckets,
+		}, []string{"operation", "endpoint"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Name:        "retries_total",
+			Help:        "Total number of retried Beosin API requests.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"operation", "endpoint"}),
+		cacheHit: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Name:        "cache_hits_total",
+			Help:        "Total number of Beosin API calls served from the response cache.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"operation", "endpoint"}),
+		credits: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   cfg.namespace,
+			Name:        "remaining_credits",
+			Help:        "Remaining credits reported by the last successful account balance query.",
+			ConstLabels: cfg.constLabels,
+		}),
+	}
+}
+
+// ObserveCall implements beosin.MetricsCollector
+func (c *Collector) ObserveCall(info *beosin.CallInfo, duration time.Duration, err error) {
+	c.requests.WithLabelValues(info.Operation, info.Endpoint, info.ChainID, codeLabel(info, err), string(info.RiskLevel)).Inc()
+	c.latency.WithLabelValues(info.Operation, info.Endpoint).Observe(duration.Seconds())
+	if info.Attempts > 1 {
+		c.retries.WithLabelValues(info.Operation, info.Endpoint).Add(float64(info.Attempts - 1))
+	}
+	if info.CacheHit {
+		c.cacheHit.WithLabelValues(info.Operation, info.Endpoint).Inc()
+	}
+}
+
+// SetAccountBalance implements beosin.MetricsCollector
+func (c *Collector) SetAccountBalance(credits int64) {
+	c.credits.Set(float64(credits))
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.retries.Describe(ch)
+	c.cacheHit.Describe(ch)
+	c.credits.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.retries.Collect(ch)
+	c.cacheHit.Collect(ch)
+	c.credits.Collect(ch)
+}
+
+// codeLabel returns the API code of a call, or the kind of failure if there is none
+func codeLabel(info *beosin.CallInfo, err error) string {
+	if info.Code != 0 {
+		return strconv.Itoa(info.Code)
+	}
+	if err == nil {
+		return "ok"
+	}
+	var httpErr *beosin.HTTPError
+	if errors.As(err, &httpErr) {
+		return "http_" + strconv.Itoa(httpErr.StatusCode)
+	}
+	return "error"
+}
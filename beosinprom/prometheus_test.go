@@ -0,0 +1,44 @@
+package beosinprom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollector tests that calls and the account balance are recorded
+func TestCollector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case beosin.EndpointAccountBalance:
+			fmt.Fprint(w, `{"code":200,"msg":"success","data":{"surplusIntegral":1234}}`)
+		default:
+			fmt.Fprint(w, `{"code":40022,"msg":"address error"}`)
+		}
+	}))
+	defer server.Close()
+
+	collector := NewCollector()
+	client := beosin.NewClient("id", "secret", beosin.WithBaseURL(server.URL), beosin.WithMetrics(collector))
+	ctx := context.Background()
+
+	if _, err := client.GetAccountBalance(ctx); err != nil {
+		t.Fatalf("GetAccountBalance failed: %v", err)
+	}
+	if _, err := client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainBSC, Address: "bad"}); err == nil {
+		t.Fatal("Expected VASPQuery to fail")
+	}
+
+	if got := testutil.ToFloat64(collector.credits); got != 1234 {
+		t.Errorf("Expected 1234 credits, got %v", got)
+	}
+	failed := collector.requests.WithLabelValues(beosin.OperationVASPQuery, beosin.EndpointVASP, beosin.ChainBSC, "40022", "")
+	if got := testutil.ToFloat64(failed); got != 1 {
+		t.Errorf("Expected 1 failed VASP call, got %v", got)
+	}
+}
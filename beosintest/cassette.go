@@ -0,0 +1,272 @@
+package beosintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// CassetteMode controls whether a cassette records or replays interactions
+type CassetteMode int
+
+const (
+	// CassetteRecord sends every request and records it, replacing the cassette contents
+	CassetteRecord CassetteMode = iota
+
+	// CassetteReplay serves every request from the cassette and fails on unmatched requests
+	CassetteReplay
+
+	// CassetteReplayOrRecord serves recorded requests and sends and records the others
+	CassetteReplayOrRecord
+
+	// CassettePassthrough sends every request without recording
+	CassettePassthrough
+)
+
+// redactedValue replaces the values of redacted headers
+const redactedValue = "REDACTED"
+
+// ErrUnmatchedRequest is returned when a replayed request has no recorded interaction
+var ErrUnmatchedRequest = errors.New("beosintest: no recorded interaction matches the request")
+
+// RecordedRequest is the request of a recorded interaction
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// RecordedResponse is the response of a recorded interaction
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// cassetteFile is the file format of a cassette
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// CassetteOption configures a cassette
+type CassetteOption func(*Cassette)
+
+// WithTransport sets the transport used to send requests that are not replayed
+// (defaults to http.DefaultTransport)
+func WithTransport(transport http.RoundTripper) CassetteOption {
+	return func(c *Cassette) {
+		c.transport = transport
+	}
+}
+
+// WithRedactedHeaders adds request and response headers whose values are not written to
+// the cassette, e.g. tracking IDs of the sandbox. APP-SECRET, Cookie and Set-Cookie are
+// always redacted.
+func WithRedactedHeaders(names ...string) CassetteOption {
+	return func(c *Cassette) {
+		for _, name := range names {
+			c.redacted = append(c.redacted, http.CanonicalHeaderKey(name))
+		}
+	}
+}
+
+// Cassette is an http.RoundTripper that records interactions with the API into a JSON file
+// and replays them. Use it with beosin.WithHTTPClient:
+//
+//	cassette, err := beosintest.NewCassette("testdata/deposit.json", beosintest.CassetteReplayOrRecord)
+//	client := beosin.NewClient(appID, appSecret, beosin.WithHTTPClient(cassette.Client()))
+//	defer cassette.Save()
+//
+// Requests are matched on method, path and query parameters regardless of their order.
+// Identical requests are replayed in the order they were recorded; once all are used,
+// the last one is repeated.
+type Cassette struct {
+	path      string
+	mode      CassetteMode
+	transport http.RoundTripper
+	redacted  []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	dirty        bool
+}
+
+// NewCassette opens a cassette file. The file must exist in CassetteReplay mode.
+func NewCassette(path string, mode CassetteMode, opts ...CassetteOption) (*Cassette, error) {
+	c := &Cassette{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		redacted:  []string{"App-Secret", "Cookie", "Set-Cookie"},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if mode == CassetteReplay || mode == CassetteReplayOrRecord {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			var file cassetteFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				return nil, fmt.Errorf("beosintest: failed to parse cassette %s: %w", path, err)
+			}
+			c.interactions = file.Interactions
+			c.used = make([]bool, len(file.Interactions))
+		case mode == CassetteReplay || !errors.Is(err, os.ErrNotExist):
+			return nil, fmt.Errorf("beosintest: failed to read cassette: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// Client returns an HTTP client using the cassette as transport
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Interactions returns the interactions of the cassette
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query().Encode()
+
+	if c.mode == CassetteReplay || c.mode == CassetteReplayOrRecord {
+		if interaction, ok := c.match(req.Method, req.URL.Path, query); ok {
+			return replay(req, interaction.Response), nil
+		}
+		if c.mode == CassetteReplay {
+			return nil, fmt.Errorf("%w: %s %s?%s in %s", ErrUnmatchedRequest, req.Method, req.URL.Path, query, c.path)
+		}
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil || c.mode == CassettePassthrough {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.record(Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  query,
+			Header: c.redact(req.Header),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     c.redact(resp.Header),
+			Body:       string(body),
+		},
+	})
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file. In CassetteRecord mode the
+// file is always replaced, even if nothing was recorded, so that re-recording a test that
+// no longer makes calls does not leave stale interactions behind. In the other modes it
+// is only written if anything was recorded.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty && c.mode != CassetteRecord {
+		return nil
+	}
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("beosintest: failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("beosintest: failed to write cassette: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// match returns the first unused matching interaction, or the last matching one if all are used
+func (c *Cassette) match(method, path, query string) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, interaction := range c.interactions {
+		r := interaction.Request
+		if r.Method != method || r.Path != path || r.Query != query {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+	if last < 0 {
+		return Interaction{}, false
+	}
+	return c.interactions[last], true
+}
+
+// record appends an interaction
+func (c *Cassette) record(interaction Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	c.used = append(c.used, true)
+	c.dirty = true
+}
+
+// redact copies request or response headers with the values of redacted headers replaced
+func (c *Cassette) redact(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range c.redacted {
+		if _, ok := header[name]; ok {
+			header[name] = []string{redactedValue}
+		}
+	}
+	return header
+}
+
+// replay builds a response from a recorded response
+func replay(req *http.Request, recorded RecordedResponse) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.StatusCode) + " " + http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,112 @@
+package beosintest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// TestCassetteRecordReplay tests recording against a server, secret redaction and offline replay
+func TestCassetteRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "vasp.json")
+	server := NewServer()
+	server.On(beosin.EndpointVASP).ForAddress("0x1").Reply(&beosin.VASPData{Address: "0x1", IsVasp: true, VaspTags: []string{"Binance"}})
+
+	recorder, err := NewCassette(path, CassetteRecord)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	client := beosin.NewClient(DefaultAppID, DefaultAppSecret, beosin.WithBaseURL(server.URL), beosin.WithHTTPClient(recorder.Client()))
+	ctx := context.Background()
+	if _, err := client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainETH, Address: "0x1"}); err != nil {
+		t.Fatalf("VASPQuery failed: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), DefaultAppSecret) {
+		t.Error("Expected APP-SECRET to be redacted from the cassette")
+	}
+
+	player, err := NewCassette(path, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	client = beosin.NewClient(DefaultAppID, DefaultAppSecret, beosin.WithBaseURL(server.URL), beosin.WithHTTPClient(player.Client()))
+
+	resp, err := client.VASPQuery(ctx, &beosin.VASPRequest{Address: "0x1", ChainID: beosin.ChainETH})
+	if err != nil {
+		t.Fatalf("Replayed VASPQuery failed: %v", err)
+	}
+	if !resp.Data.IsVasp || len(resp.Data.VaspTags) != 1 {
+		t.Errorf("Unexpected replayed response %+v", resp.Data)
+	}
+
+	_, err = client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainETH, Address: "0x2"})
+	if !errors.Is(err, ErrUnmatchedRequest) || !strings.Contains(err.Error(), "address=0x2") {
+		t.Errorf("Expected an unmatched request error, got %v", err)
+	}
+
+	if _, err := NewCassette(filepath.Join(t.TempDir(), "missing.json"), CassetteReplay); err == nil {
+		t.Error("Expected an error for a missing cassette in replay mode")
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestCassetteRerecordAndHeaders tests that re-recording replaces a stale cassette and that
+// redacted response headers are not written
+func TestCassetteRerecordAndHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "balance.json")
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{"Set-Cookie": {"session=abc123"}, "X-Trace-Id": {"trace-42"}, "Content-Type": {"application/json"}}
+		body := `{"code":200,"msg":"success","data":{"surplusIntegral":10}}`
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})
+
+	recorder, _ := NewCassette(path, CassetteRecord, WithTransport(transport), WithRedactedHeaders("X-Trace-Id"))
+	client := beosin.NewClient("id", "secret", beosin.WithBaseURL("http://sandbox.invalid"), beosin.WithHTTPClient(recorder.Client()))
+	if _, err := client.GetAccountBalance(context.Background()); err != nil {
+		t.Fatalf("GetAccountBalance failed: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "abc123") || strings.Contains(string(data), "trace-42") || !strings.Contains(string(data), "application/json") {
+		t.Errorf("Expected only the redacted response headers to be masked:\n%s", data)
+	}
+
+	// Re-recording a test that no longer makes calls empties the cassette
+	rerecorder, _ := NewCassette(path, CassetteRecord, WithTransport(transport))
+	if err := rerecorder.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	player, err := NewCassette(path, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	if n := len(player.Interactions()); n != 0 {
+		t.Errorf("Expected the stale interaction to be removed, got %d interactions", n)
+	}
+}
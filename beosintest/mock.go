@@ -0,0 +1,176 @@
+package beosintest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// MockCall is a call received by a MockClient
+type MockCall struct {
+	// Operation is the name of the Client method (e.g. beosin.OperationVASPQuery)
+	Operation string
+
+	// Request is the request of the call, or nil for GetAccountBalance
+	Request interface{}
+}
+
+// MockClient is a beosin.Client whose methods call the corresponding function field.
+// Methods without a function return a successful low-risk response. All calls are recorded.
+//
+//	mock := &beosintest.MockClient{
+//		V4DepositTransactionAssessmentFunc: func(ctx context.Context, req *beosin.DepositRequest) (*beosin.V4TransactionRiskResponse, error) {
+//			return beosintest.NewV4TransactionRiskResponse(beosin.RiskLevelSevere, 95), nil
+//		},
+//	}
+type MockClient struct {
+	GetAccountBalanceFunc                 func(ctx context.Context) (*beosin.AccountBalanceResponse, error)
+	DepositTransactionAssessmentFunc      func(ctx context.Context, req *beosin.DepositRequest) (*beosin.TransactionRiskResponse, error)
+	WithdrawalTransactionAssessmentFunc   func(ctx context.Context, req *beosin.WithdrawalRequest) (*beosin.TransactionRiskResponse, error)
+	EOAAddressRiskAssessmentFunc          func(ctx context.Context, req *beosin.AddressRiskRequest) (*beosin.AddressRiskResponse, error)
+	MaliciousAddressQueryFunc             func(ctx context.Context, req *beosin.MaliciousAddressRequest) (*beosin.MaliciousAddressResponse, error)
+	VASPQueryFunc                         func(ctx context.Context, req *beosin.VASPRequest) (*beosin.VASPResponse, error)
+	V4EOAAddressRiskAssessmentFunc        func(ctx context.Context, req *beosin.AddressRiskRequest) (*beosin.V4AddressRiskResponse, error)
+	V4DepositTransactionAssessmentFunc    func(ctx context.Context, req *beosin.DepositRequest) (*beosin.V4TransactionRiskResponse, error)
+	V4WithdrawalTransactionAssessmentFunc func(ctx context.Context, req *beosin.WithdrawalRequest) (*beosin.V4TransactionRiskResponse, error)
+	BlackAddressScreeningFunc             func(ctx context.Context, req *beosin.BlackScreeningRequest) (*beosin.BlackScreeningResponse, error)
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// Verify interface compliance
+var _ beosin.Client = (*MockClient)(nil)
+
+// record stores a call
+func (m *MockClient) record(operation string, req interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Operation: operation, Request: req})
+}
+
+// Calls returns the calls received so far
+func (m *MockClient) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo returns the calls received so far for an operation
+func (m *MockClient) CallsTo(operation string) []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []MockCall
+	for _, call := range m.calls {
+		if call.Operation == operation {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// GetAccountBalance implements beosin.Client
+func (m *MockClient) GetAccountBalance(ctx context.Context) (*beosin.AccountBalanceResponse, error) {
+	m.record(beosin.OperationGetAccountBalance, nil)
+	if m.GetAccountBalanceFunc != nil {
+		return m.GetAccountBalanceFunc(ctx)
+	}
+	return NewAccountBalanceResponse(DefaultCredits, time.Now().AddDate(1, 0, 0)), nil
+}
+
+// DepositTransactionAssessment implements beosin.Client
+func (m *MockClient) DepositTransactionAssessment(ctx context.Context, req *beosin.DepositRequest) (*beosin.TransactionRiskResponse, error) {
+	m.record(beosin.OperationDepositTransactionAssessment, req)
+	if m.DepositTransactionAssessmentFunc != nil {
+		return m.DepositTransactionAssessmentFunc(ctx, req)
+	}
+	return NewTransactionRiskResponse(beosin.RiskLevelLow, 0), nil
+}
+
+// WithdrawalTransactionAssessment implements beosin.Client
+func (m *MockClient) WithdrawalTransactionAssessment(ctx context.Context, req *beosin.WithdrawalRequest) (*beosin.TransactionRiskResponse, error) {
+	m.record(beosin.OperationWithdrawalTransactionAssessment, req)
+	if m.WithdrawalTransactionAssessmentFunc != nil {
+		return m.WithdrawalTransactionAssessmentFunc(ctx, req)
+	}
+	return NewTransactionRiskResponse(beosin.RiskLevelLow, 0), nil
+}
+
+// EOAAddressRiskAssessment implements beosin.Client
+func (m *MockClient) EOAAddressRiskAssessment(ctx context.Context, req *beosin.AddressRiskRequest) (*beosin.AddressRiskResponse, error) {
+	m.record(beosin.OperationEOAAddressRiskAssessment, req)
+	if m.EOAAddressRiskAssessmentFunc != nil {
+		return m.EOAAddressRiskAssessmentFunc(ctx, req)
+	}
+	return NewAddressRiskResponse(beosin.RiskLevelLow, 0), nil
+}
+
+// MaliciousAddressQuery implements beosin.Client
+func (m *MockClient) MaliciousAddressQuery(ctx context.Context, req *beosin.MaliciousAddressRequest) (*beosin.MaliciousAddressResponse, error) {
+	m.record(beosin.OperationMaliciousAddressQuery, req)
+	if m.MaliciousAddressQueryFunc != nil {
+		return m.MaliciousAddressQueryFunc(ctx, req)
+	}
+	if req == nil {
+		return NewMaliciousAddressResponse("", false, false), nil
+	}
+	return NewMaliciousAddressResponse(req.Address, false, false), nil
+}
+
+// VASPQuery implements beosin.Client
+func (m *MockClient) VASPQuery(ctx context.Context, req *beosin.VASPRequest) (*beosin.VASPResponse, error) {
+	m.record(beosin.OperationVASPQuery, req)
+	if m.VASPQueryFunc != nil {
+		return m.VASPQueryFunc(ctx, req)
+	}
+	if req == nil {
+		return NewVASPResponse(""), nil
+	}
+	return NewVASPResponse(req.Address), nil
+}
+
+// V4EOAAddressRiskAssessment implements beosin.Client
+func (m *MockClient) V4EOAAddressRiskAssessment(ctx context.Context, req *beosin.AddressRiskRequest) (*beosin.V4AddressRiskResponse, error) {
+	m.record(beosin.OperationV4EOAAddressRiskAssessment, req)
+	if m.V4EOAAddressRiskAssessmentFunc != nil {
+		return m.V4EOAAddressRiskAssessmentFunc(ctx, req)
+	}
+	return NewV4AddressRiskResponse(beosin.RiskLevelLow, 0), nil
+}
+
+// V4DepositTransactionAssessment implements beosin.Client
+func (m *MockClient) V4DepositTransactionAssessment(ctx context.Context, req *beosin.DepositRequest) (*beosin.V4TransactionRiskResponse, error) {
+	m.record(beosin.OperationV4DepositTransactionAssessment, req)
+	if m.V4DepositTransactionAssessmentFunc != nil {
+		return m.V4DepositTransactionAssessmentFunc(ctx, req)
+	}
+	return NewV4TransactionRiskResponse(beosin.RiskLevelLow, 0), nil
+}
+
+// V4WithdrawalTransactionAssessment implements beosin.Client
+func (m *MockClient) V4WithdrawalTransactionAssessment(ctx context.Context, req *beosin.WithdrawalRequest) (*beosin.V4TransactionRiskResponse, error) {
+	m.record(beosin.OperationV4WithdrawalTransactionAssessment, req)
+	if m.V4WithdrawalTransactionAssessmentFunc != nil {
+		return m.V4WithdrawalTransactionAssessmentFunc(ctx, req)
+	}
+	return NewV4TransactionRiskResponse(beosin.RiskLevelLow, 0), nil
+}
+
+// BlackAddressScreening implements beosin.Client
+func (m *MockClient) BlackAddressScreening(ctx context.Context, req *beosin.BlackScreeningRequest) (*beosin.BlackScreeningResponse, error) {
+	m.record(beosin.OperationBlackAddressScreening, req)
+	if m.BlackAddressScreeningFunc != nil {
+		return m.BlackAddressScreeningFunc(ctx, req)
+	}
+	return NewBlackScreeningResponse(beosin.BlackScreeningData{}), nil
+}
@@ -0,0 +1,44 @@
+package beosintest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// TestMockClient tests function fields, default responses and call recording
+func TestMockClient(t *testing.T) {
+	mock := &MockClient{
+		BlackAddressScreeningFunc: func(ctx context.Context, req *beosin.BlackScreeningRequest) (*beosin.BlackScreeningResponse, error) {
+			return NewSanctionedScreeningResponse(), nil
+		},
+		V4DepositTransactionAssessmentFunc: func(ctx context.Context, req *beosin.DepositRequest) (*beosin.V4TransactionRiskResponse, error) {
+			return nil, beosin.ErrTaskExecuting
+		},
+	}
+	var client beosin.Client = mock
+	ctx := context.Background()
+
+	screening, err := client.BlackAddressScreening(ctx, &beosin.BlackScreeningRequest{Platform: "eth", Address: "0x1"})
+	if err != nil || !screening.Data.Sanction || !screening.Data.HasAnyRisk() {
+		t.Errorf("Expected a sanctioned screening result, got %+v, %v", screening, err)
+	}
+	if _, err := client.V4DepositTransactionAssessment(ctx, &beosin.DepositRequest{ChainID: beosin.ChainETH, Hash: "0x1"}); !errors.Is(err, beosin.ErrTaskExecuting) {
+		t.Errorf("Expected the programmed error, got %v", err)
+	}
+
+	risk, err := client.V4EOAAddressRiskAssessment(ctx, &beosin.AddressRiskRequest{ChainID: beosin.ChainETH, Address: "0x2"})
+	if err != nil || risk.Data.RiskLevel != beosin.RiskLevelLow || !risk.IsSuccess() {
+		t.Errorf("Expected a default low risk response, got %+v, %v", risk, err)
+	}
+
+	if calls := mock.Calls(); len(calls) != 3 {
+		t.Fatalf("Expected 3 recorded calls, got %d", len(calls))
+	}
+	calls := mock.CallsTo(beosin.OperationBlackAddressScreening)
+	if len(calls) != 1 || calls[0].Request.(*beosin.BlackScreeningRequest).Address != "0x1" {
+		t.Errorf("Unexpected recorded screening calls: %+v", calls)
+	}
+}
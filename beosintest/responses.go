@@ -0,0 +1,109 @@
+package beosintest
+
+import (
+	"net/http"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// success returns the envelope of a successful response
+func success() beosin.BaseResponse {
+	return beosin.BaseResponse{Code: http.StatusOK, Msg: "success"}
+}
+
+// NewAccountBalanceResponse returns a balance response with the given credits and equity end date
+func NewAccountBalanceResponse(credits int64, equityEnd time.Time) *beosin.AccountBalanceResponse {
+	return &beosin.AccountBalanceResponse{
+		BaseResponse: success(),
+		Data: &beosin.AccountBalanceData{
+			SurplusIntegral: credits,
+			EquityStartDate: equityEnd.AddDate(-1, 0, 0).Unix(),
+			EquityEndDate:   equityEnd.Unix(),
+		},
+	}
+}
+
+// NewTransactionRiskResponse returns a transaction assessment with the given risk level and score
+func NewTransactionRiskResponse(level beosin.RiskLevel, score float64) *beosin.TransactionRiskResponse {
+	return &beosin.TransactionRiskResponse{
+		BaseResponse: success(),
+		Data:         &beosin.TransactionRiskData{Score: score, RiskLevel: level},
+	}
+}
+
+// NewV4TransactionRiskResponse returns a V4 transaction assessment with the given risk level and score
+func NewV4TransactionRiskResponse(level beosin.RiskLevel, score float64) *beosin.V4TransactionRiskResponse {
+	return &beosin.V4TransactionRiskResponse{
+		BaseResponse: success(),
+		Data:         &beosin.V4TransactionRiskData{Score: score, RiskLevel: level},
+	}
+}
+
+// NewAddressRiskResponse returns an address assessment with the given risk level and score in every direction
+func NewAddressRiskResponse(level beosin.RiskLevel, score float64) *beosin.AddressRiskResponse {
+	return &beosin.AddressRiskResponse{
+		BaseResponse: success(),
+		Data: &beosin.AddressRiskData{
+			Score:         score,
+			RiskLevel:     level,
+			IncomingScore: score,
+			IncomingLevel: level,
+			OutgoingScore: score,
+			OutgoingLevel: level,
+			RiskTagScore:  score,
+			RiskTagLevel:  level,
+		},
+	}
+}
+
+// NewV4AddressRiskResponse returns a V4 address assessment with the given risk level and score in every direction
+func NewV4AddressRiskResponse(level beosin.RiskLevel, score float64) *beosin.V4AddressRiskResponse {
+	return &beosin.V4AddressRiskResponse{
+		BaseResponse: success(),
+		Data: &beosin.V4AddressRiskData{
+			Score:         score,
+			RiskLevel:     level,
+			IncomingScore: score,
+			IncomingLevel: level,
+			OutgoingScore: score,
+			OutgoingLevel: level,
+			RiskTagScore:  score,
+			RiskTagLevel:  level,
+		},
+	}
+}
+
+// NewMaliciousAddressResponse returns a malicious address result. Malicious addresses are
+// tagged as phishing and sanctioned addresses as OFAC SDN entries.
+func NewMaliciousAddressResponse(address string, malicious, sanctioned bool) *beosin.MaliciousAddressResponse {
+	data := &beosin.MaliciousAddressData{Address: address, IsMalicious: malicious, IsSanction: sanctioned}
+	if malicious {
+		data.MaliceDetail = &beosin.MaliceDetail{
+			Source:     "beosintest",
+			MaliceTags: []beosin.MaliceTag{{TagType: "Scam", Tag: "Phishing"}},
+		}
+	}
+	if sanctioned {
+		data.SanctionDetail = &beosin.SanctionDetail{Standard: "OFAC", Tag: "SDN", Entity: "beosintest"}
+	}
+	return &beosin.MaliciousAddressResponse{BaseResponse: success(), Data: data}
+}
+
+// NewVASPResponse returns a VASP result; the address is a VASP if any tags are given
+func NewVASPResponse(address string, tags ...string) *beosin.VASPResponse {
+	return &beosin.VASPResponse{
+		BaseResponse: success(),
+		Data:         &beosin.VASPData{Address: address, IsVasp: len(tags) > 0, VaspTags: tags},
+	}
+}
+
+// NewBlackScreeningResponse returns a screening result with the given flags
+func NewBlackScreeningResponse(data beosin.BlackScreeningData) *beosin.BlackScreeningResponse {
+	return &beosin.BlackScreeningResponse{BaseResponse: success(), Data: &data}
+}
+
+// NewSanctionedScreeningResponse returns a screening result of a sanctioned address
+func NewSanctionedScreeningResponse() *beosin.BlackScreeningResponse {
+	return NewBlackScreeningResponse(beosin.BlackScreeningData{Sanction: true})
+}
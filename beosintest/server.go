@@ -0,0 +1,441 @@
+// Package beosintest provides an in-process fake of the Beosin API for tests.
+//
+// The fake serves every endpoint of the client, checks the APPID and APP-SECRET
+// headers and records the calls it receives. Responses can be programmed per
+// endpoint, chain, address or hash:
+//
+//	server := beosintest.NewServer()
+//	defer server.Close()
+//
+//	server.On(beosin.EndpointV4Deposit).ForHash("0xabc").Reply(&beosin.V4TransactionRiskData{RiskLevel: beosin.RiskLevelSevere})
+//	server.On(beosin.EndpointVASP).ReplyError(beosin.ErrCodeTaskExecuting, "task executing").Times(1)
+//
+//	client := server.Client()
+//
+// Cassette records interactions with the real API into a file and replays them
+// offline for deterministic integration tests. MockClient implements beosin.Client
+// without HTTP for unit tests of code depending on the interface.
+package beosintest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// Default credentials accepted by the server
+const (
+	DefaultAppID     = "test-app-id"
+	DefaultAppSecret = "test-app-secret"
+)
+
+// DefaultCredits is the default balance of the fake account
+const DefaultCredits = 1000000
+
+// endpointParams lists the required query parameters per endpoint
+var endpointParams = map[string][]string{
+	beosin.EndpointAccountBalance:   nil,
+	beosin.EndpointDeposit:          {"chainId", "hash"},
+	beosin.EndpointWithdraw:         {"chainId", "hash"},
+	beosin.EndpointAddressRisk:      {"chainId", "address"},
+	beosin.EndpointMaliciousAddress: {"chainId", "address"},
+	beosin.EndpointVASP:             {"chainId", "address"},
+	beosin.EndpointV4AddressRisk:    {"chainId", "address"},
+	beosin.EndpointV4Deposit:        {"chainId", "hash"},
+	beosin.EndpointV4Withdraw:       {"chainId", "hash"},
+	beosin.EndpointBlackScreening:   {"platform", "address"},
+}
+
+// Call is a request received by the server
+type Call struct {
+	// Method is the HTTP method
+	Method string
+
+	// Endpoint is the request path
+	Endpoint string
+
+	// Query holds the query parameters
+	Query url.Values
+
+	// Header holds the request headers
+	Header http.Header
+
+	// Time is when the request was received
+	Time time.Time
+}
+
+// config holds the server configuration
+type config struct {
+	appID     string
+	appSecret string
+	credits   int64
+	equityEnd time.Time
+	latency   time.Duration
+}
+
+// Option configures the server
+type Option func(*config)
+
+// WithCredentials sets the credentials the server accepts
+func WithCredentials(appID, appSecret string) Option {
+	return func(c *config) {
+		c.appID = appID
+		c.appSecret = appSecret
+	}
+}
+
+// WithBalance sets the initial credits and equity end date of the fake account.
+// Every successful call other than a balance query uses one credit.
+func WithBalance(credits int64, equityEnd time.Time) Option {
+	return func(c *config) {
+		c.credits = credits
+		c.equityEnd = equityEnd
+	}
+}
+
+// WithLatency delays every response
+func WithLatency(latency time.Duration) Option {
+	return func(c *config) {
+		c.latency = latency
+	}
+}
+
+// Server is a fake Beosin API server
+type Server struct {
+	// URL is the base URL of the server
+	URL string
+
+	config config
+	server *httptest.Server
+
+	mu    sync.Mutex
+	rules []*Rule
+	calls []Call
+}
+
+// NewServer starts a fake server. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	cfg := config{
+		appID:     DefaultAppID,
+		appSecret: DefaultAppSecret,
+		credits:   DefaultCredits,
+		equityEnd: time.Now().AddDate(1, 0, 0),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Server{config: cfg}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the server using the accepted credentials
+func (s *Server) Client(opts ...beosin.Option) beosin.Client {
+	opts = append([]beosin.Option{beosin.WithBaseURL(s.URL)}, opts...)
+	return beosin.NewClient(s.config.appID, s.config.appSecret, opts...)
+}
+
+// Calls returns the requests received so far
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.calls)
+}
+
+// CallsTo returns the requests received so far for an endpoint
+func (s *Server) CallsTo(endpoint string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var calls []Call
+	for _, call := range s.calls {
+		if call.Endpoint == endpoint {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Credits returns the remaining credits of the fake account
+func (s *Server) Credits() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config.credits
+}
+
+// Reset removes all programmed responses and recorded calls
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = nil
+	s.calls = nil
+}
+
+// On programs a response for an endpoint. Rules added later take precedence;
+// requests matching no rule get a default low-risk response.
+func (s *Server) On(endpoint string) *Rule {
+	r := &Rule{server: s, endpoint: endpoint, params: make(map[string]string)}
+	s.mu.Lock()
+	s.rules = append(s.rules, r)
+	s.mu.Unlock()
+	return r
+}
+
+// Rule is a programmed response
+type Rule struct {
+	server   *Server
+	endpoint string
+	params   map[string]string
+
+	data    interface{}
+	code    int
+	message string
+	status  int
+	body    string
+	delay   time.Duration
+	times   int
+	used    int
+}
+
+// update applies a change to the rule under the server lock
+func (r *Rule) update(fn func()) *Rule {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	fn()
+	return r
+}
+
+// ForParam restricts the rule to requests with the query parameter value (case-insensitive)
+func (r *Rule) ForParam(name, value string) *Rule {
+	return r.update(func() { r.params[name] = value })
+}
+
+// ForChain restricts the rule to requests for the chain ID
+func (r *Rule) ForChain(chainID string) *Rule {
+	return r.ForParam("chainId", chainID)
+}
+
+// ForPlatform restricts the rule to black address screening requests for the platform
+func (r *Rule) ForPlatform(platform string) *Rule {
+	return r.ForParam("platform", platform)
+}
+
+// ForAddress restricts the rule to requests for the address
+func (r *Rule) ForAddress(address string) *Rule {
+	return r.ForParam("address", address)
+}
+
+// ForHash restricts the rule to requests for the transaction hash
+func (r *Rule) ForHash(hash string) *Rule {
+	return r.ForParam("hash", hash)
+}
+
+// Reply responds with a successful API response holding data
+func (r *Rule) Reply(data interface{}) *Rule {
+	return r.update(func() {
+		r.data, r.code, r.status = data, http.StatusOK, 0
+	})
+}
+
+// ReplyError responds with an API error code, e.g. beosin.ErrCodeTaskExecuting
+func (r *Rule) ReplyError(code int, message string) *Rule {
+	return r.update(func() {
+		r.data, r.code, r.message, r.status = nil, code, message, 0
+	})
+}
+
+// ReplyStatus responds with an HTTP failure status and body
+func (r *Rule) ReplyStatus(status int, body string) *Rule {
+	return r.update(func() {
+		r.status, r.body = status, body
+	})
+}
+
+// Delay delays the response, in addition to the server latency
+func (r *Rule) Delay(delay time.Duration) *Rule {
+	return r.update(func() { r.delay = delay })
+}
+
+// Times limits how often the rule is used (0 means unlimited)
+func (r *Rule) Times(n int) *Rule {
+	return r.update(func() { r.times = n })
+}
+
+// matches checks if the rule applies to a request; the caller must hold the lock
+func (r *Rule) matches(endpoint string, query url.Values) bool {
+	if r.endpoint != endpoint || (r.times > 0 && r.used >= r.times) {
+		return false
+	}
+	for name, value := range r.params {
+		if !strings.EqualFold(query.Get(name), value) {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns the most recently added rule matching the request; the caller must hold the lock
+func (s *Server) match(endpoint string, query url.Values) *Rule {
+	for i := len(s.rules) - 1; i >= 0; i-- {
+		if s.rules[i].matches(endpoint, query) {
+			return s.rules[i]
+		}
+	}
+	return nil
+}
+
+// use counts a response of the matching rule and returns a copy of it. With statusOnly,
+// only a rule replying with an HTTP failure is used, since other responses need the
+// request parameters.
+func (s *Server) use(endpoint string, query url.Values, statusOnly bool) (Rule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.match(endpoint, query)
+	if r == nil || statusOnly && r.status == 0 {
+		return Rule{}, false
+	}
+	r.used++
+	return *r, true
+}
+
+// response is the JSON envelope of the API
+type response struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// handle serves a request
+func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+		Query:    query,
+		Header:   req.Header.Clone(),
+		Time:     time.Now(),
+	})
+
+	delay := s.config.latency
+	if r := s.match(req.URL.Path, query); r != nil {
+		delay += r.delay
+	}
+	s.mu.Unlock()
+
+	if !sleep(req.Context(), delay) {
+		return
+	}
+
+	required, known := endpointParams[req.URL.Path]
+	switch {
+	case !known:
+		http.NotFound(w, req)
+		return
+	case req.Header.Get("APPID") != s.config.appID || req.Header.Get("APP-SECRET") != s.config.appSecret:
+		// Rejected credentials get a bare status without an API envelope
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	missing := ""
+	for _, name := range required {
+		if query.Get(name) == "" {
+			missing = name
+			break
+		}
+	}
+
+	// A rule is only used up when its response is served
+	rule, matched := s.use(req.URL.Path, query, missing != "")
+	if matched && rule.status != 0 {
+		w.WriteHeader(rule.status)
+		w.Write([]byte(rule.body))
+		return
+	}
+	if missing != "" {
+		writeJSON(w, http.StatusOK, response{Code: beosin.ErrCodeParameterError, Msg: "missing parameter " + missing})
+		return
+	}
+
+	resp := response{Code: http.StatusOK, Msg: "success"}
+	switch {
+	case matched && rule.code != http.StatusOK && rule.code != 0:
+		resp.Code, resp.Msg = rule.code, rule.message
+	case matched && rule.data != nil:
+		resp.Data = rule.data
+	default:
+		resp.Data = s.defaultData(req.URL.Path, query)
+	}
+
+	if resp.Code == http.StatusOK && req.URL.Path != beosin.EndpointAccountBalance {
+		s.mu.Lock()
+		s.config.credits--
+		s.mu.Unlock()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// defaultData returns the default low-risk response data of an endpoint
+func (s *Server) defaultData(endpoint string, query url.Values) interface{} {
+	address := query.Get("address")
+	switch endpoint {
+	case beosin.EndpointAccountBalance:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return NewAccountBalanceResponse(s.config.credits, s.config.equityEnd).Data
+	case beosin.EndpointDeposit, beosin.EndpointWithdraw:
+		return NewTransactionRiskResponse(beosin.RiskLevelLow, 0).Data
+	case beosin.EndpointAddressRisk:
+		return NewAddressRiskResponse(beosin.RiskLevelLow, 0).Data
+	case beosin.EndpointMaliciousAddress:
+		return NewMaliciousAddressResponse(address, false, false).Data
+	case beosin.EndpointVASP:
+		return NewVASPResponse(address).Data
+	case beosin.EndpointV4AddressRisk:
+		return NewV4AddressRiskResponse(beosin.RiskLevelLow, 0).Data
+	case beosin.EndpointV4Deposit, beosin.EndpointV4Withdraw:
+		return NewV4TransactionRiskResponse(beosin.RiskLevelLow, 0).Data
+	case beosin.EndpointBlackScreening:
+		return NewBlackScreeningResponse(beosin.BlackScreeningData{}).Data
+	}
+	return nil
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// sleep waits for the duration and reports whether the request is still active
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
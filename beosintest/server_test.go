@@ -0,0 +1,107 @@
+package beosintest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// TestServerDefaults tests the default responses, credit accounting and call recording
+func TestServerDefaults(t *testing.T) {
+	server := NewServer(WithBalance(10, time.Now().Add(time.Hour)))
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	resp, err := client.V4EOAAddressRiskAssessment(ctx, &beosin.AddressRiskRequest{ChainID: beosin.ChainETH, Address: "0x1"})
+	if err != nil {
+		t.Fatalf("V4EOAAddressRiskAssessment failed: %v", err)
+	}
+	if resp.Data.RiskLevel != beosin.RiskLevelLow {
+		t.Errorf("Expected a low risk default, got %s", resp.Data.RiskLevel)
+	}
+
+	balance, err := client.GetAccountBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetAccountBalance failed: %v", err)
+	}
+	if balance.Data.SurplusIntegral != 9 {
+		t.Errorf("Expected 9 credits, got %d", balance.Data.SurplusIntegral)
+	}
+
+	calls := server.CallsTo(beosin.EndpointV4AddressRisk)
+	if len(calls) != 1 || calls[0].Query.Get("address") != "0x1" || calls[0].Header.Get("APPID") != DefaultAppID {
+		t.Errorf("Unexpected recorded calls: %+v", calls)
+	}
+
+	_, err = client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainETH})
+	if !errors.Is(err, beosin.ErrParameterInvalid) {
+		t.Errorf("Expected a parameter error for a missing address, got %v", err)
+	}
+}
+
+// TestServerRules tests programmed responses, error injection and credential checks
+func TestServerRules(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	hash := "0xabc"
+	server.On(beosin.EndpointV4Deposit).ForChain(beosin.ChainETH).ForHash(hash).
+		Reply(&beosin.V4TransactionRiskData{Score: 95, RiskLevel: beosin.RiskLevelSevere})
+	server.On(beosin.EndpointV4Deposit).ForHash(hash).ReplyError(beosin.ErrCodeTaskExecuting, "task executing").Times(1)
+	server.On(beosin.EndpointBlackScreening).ReplyStatus(http.StatusBadGateway, "bad gateway")
+	server.On(beosin.EndpointVASP).Reply(&beosin.VASPData{IsVasp: true}).Times(1)
+
+	client := server.Client(beosin.WithMaxRetries(0))
+	ctx := context.Background()
+	req := &beosin.DepositRequest{ChainID: beosin.ChainETH, Hash: "0xABC"}
+
+	// Rejected requests do not use up rules limited with Times
+	wrong := beosin.NewClient("id", "wrong", beosin.WithBaseURL(server.URL))
+	if _, err := wrong.V4DepositTransactionAssessment(ctx, req); !beosin.IsAuthError(err) {
+		t.Errorf("Expected an auth error for wrong credentials, got %v", err)
+	}
+	if _, err := client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainETH}); !errors.Is(err, beosin.ErrParameterInvalid) {
+		t.Errorf("Expected a parameter error for a missing address, got %v", err)
+	}
+	if resp, err := client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainETH, Address: "0x1"}); err != nil || !resp.Data.IsVasp {
+		t.Errorf("Expected the programmed VASP response, got %+v, %v", resp, err)
+	}
+
+	if _, err := client.V4DepositTransactionAssessment(ctx, req); !errors.Is(err, beosin.ErrTaskExecuting) {
+		t.Fatalf("Expected the injected task executing error, got %v", err)
+	}
+	resp, err := client.V4DepositTransactionAssessment(ctx, req)
+	if err != nil || resp.Data.RiskLevel != beosin.RiskLevelSevere {
+		t.Fatalf("Expected the programmed severe response, got %+v, %v", resp, err)
+	}
+
+	_, err = client.BlackAddressScreening(ctx, &beosin.BlackScreeningRequest{Platform: "eth", Address: "0x1"})
+	var httpErr *beosin.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected the injected HTTP failure, got %v", err)
+	}
+
+	if _, err := wrong.GetAccountBalance(ctx); !beosin.IsAuthError(err) {
+		t.Errorf("Expected an auth error for wrong credentials, got %v", err)
+	}
+}
+
+// TestServerLatency tests that injected latency respects the client's context
+func TestServerLatency(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.On(beosin.EndpointVASP).Reply(&beosin.VASPData{IsVasp: true}).Delay(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := server.Client(beosin.WithMaxRetries(0)).VASPQuery(ctx, &beosin.VASPRequest{ChainID: beosin.ChainETH, Address: "0x1"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitFailureThreshold is the default number of consecutive failures that opens a circuit
+	DefaultCircuitFailureThreshold = 5
+
+	// DefaultCircuitOpenTimeout is the default time a circuit stays open before allowing a probe
+	DefaultCircuitOpenTimeout = 30 * time.Second
+)
+
+// Endpoint groups used by DefaultEndpointGroup
+const (
+	EndpointGroupBasic        = "basic"
+	EndpointGroupCompliance   = "compliance"
+	EndpointGroupComplianceV4 = "compliance-v4"
+	EndpointGroupSecurity     = "security"
+)
+
+// ErrCircuitOpen is matched by errors returned while a circuit breaker is open
+var ErrCircuitOpen = errors.New("beosin: circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects all requests
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitStateChange describes a circuit breaker state transition
+type CircuitStateChange struct {
+	// Group is the endpoint group of the circuit
+	Group string
+
+	// From is the previous state
+	From CircuitState
+
+	// To is the new state
+	To CircuitState
+
+	// Err is the failure that opened the circuit, if any
+	Err error
+}
+
+// CircuitOpenError is returned when a request is rejected by an open circuit breaker
+type CircuitOpenError struct {
+	// Group is the endpoint group of the circuit
+	Group string
+
+	// RetryAfter is the time until the circuit allows a probe request
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("beosin: circuit breaker is open: group=%s, retry after %s", e.Group, e.RetryAfter)
+}
+
+// Is reports whether target is ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerOptions configures the circuit breakers of a client
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens a circuit
+	FailureThreshold int
+
+	// OpenTimeout is how long a circuit stays open before a probe request is allowed
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxRequests is the number of concurrent probe requests allowed when half-open
+	HalfOpenMaxRequests int
+
+	// GroupFunc maps an endpoint to its circuit (defaults to DefaultEndpointGroup)
+	GroupFunc func(endpoint string) string
+
+	// OnStateChange is called after a circuit changes state
+	OnStateChange func(CircuitStateChange)
+}
+
+// applyDefaults fills unset options with default values
+func (o *CircuitBreakerOptions) applyDefaults() {
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if o.OpenTimeout <= 0 {
+		o.OpenTimeout = DefaultCircuitOpenTimeout
+	}
+	if o.HalfOpenMaxRequests <= 0 {
+		o.HalfOpenMaxRequests = 1
+	}
+	if o.GroupFunc == nil {
+		o.GroupFunc = DefaultEndpointGroup
+	}
+}
+
+// DefaultEndpointGroup groups endpoints by API module
+func DefaultEndpointGroup(endpoint string) string {
+	switch {
+	case strings.HasPrefix(endpoint, "/api/v4/kyt/"):
+		return EndpointGroupComplianceV4
+	case strings.Contains(endpoint, "/kyt/"):
+		return EndpointGroupCompliance
+	case endpoint == EndpointBlackScreening:
+		return EndpointGroupSecurity
+	}
+	return EndpointGroupBasic
+}
+
+// isCircuitFailure checks if an error counts towards opening a circuit
+func isCircuitFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false
+	}
+	return true
+}
+
+// errNotSent is recorded for requests that were given up before being sent
+var errNotSent = errors.New("beosin: request not sent")
+
+// circuit is the breaker state of one endpoint group
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+
+	// generation counts state changes, so that probes of an earlier
+	// half-open state are not released twice
+	generation uint64
+}
+
+// circuitBreakers holds a circuit per endpoint group
+type circuitBreakers struct {
+	options  CircuitBreakerOptions
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// newCircuitBreakers creates circuit breakers with the given options
+func newCircuitBreakers(options CircuitBreakerOptions) *circuitBreakers {
+	options.applyDefaults()
+	return &circuitBreakers{
+		options:  options,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// allow checks if a request to the endpoint may be sent. On success, the returned
+// function must be called with the outcome of the request.
+func (b *circuitBreakers) allow(endpoint string) (func(context.Context, error), error) {
+	if b == nil {
+		return func(context.Context, error) {}, nil
+	}
+	group := b.options.GroupFunc(endpoint)
+
+	b.mu.Lock()
+	c, ok := b.circuits[group]
+	if !ok {
+		c = &circuit{}
+		b.circuits[group] = c
+	}
+
+	var change *CircuitStateChange
+	if c.state == CircuitOpen {
+		if wait := b.options.OpenTimeout - time.Since(c.openedAt); wait > 0 {
+			b.mu.Unlock()
+			return nil, &CircuitOpenError{Group: group, RetryAfter: wait}
+		}
+		change = b.transition(group, c, CircuitHalfOpen, nil)
+	}
+	if c.state == CircuitHalfOpen {
+		if c.probes >= b.options.HalfOpenMaxRequests {
+			b.mu.Unlock()
+			b.notify(change)
+			return nil, &CircuitOpenError{Group: group}
+		}
+		c.probes++
+	}
+	probe := c.state == CircuitHalfOpen
+	generation := c.generation
+	b.mu.Unlock()
+	b.notify(change)
+
+	return func(ctx context.Context, err error) {
+		b.record(ctx, group, c, probe, generation, err)
+	}, nil
+}
+
+// record updates a circuit with the outcome of a request. Requests canceled by the
+// caller or never sent say nothing about the API and are ignored; deadlines and other
+// timeouts count as failures.
+func (b *circuitBreakers) record(ctx context.Context, group string, c *circuit, probe bool, generation uint64, err error) {
+	b.mu.Lock()
+	if probe && c.generation == generation && c.probes > 0 {
+		c.probes--
+	}
+	if errors.Is(err, errNotSent) || errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		b.mu.Unlock()
+		return
+	}
+
+	var change *CircuitStateChange
+	if isCircuitFailure(err) {
+		c.failures++
+		if c.state == CircuitHalfOpen || c.failures >= b.options.FailureThreshold {
+			change = b.transition(group, c, CircuitOpen, err)
+		}
+	} else {
+		c.failures = 0
+		if c.state == CircuitHalfOpen {
+			change = b.transition(group, c, CircuitClosed, nil)
+		}
+	}
+	b.mu.Unlock()
+	b.notify(change)
+}
+
+// transition changes the state of a circuit; the caller must hold the lock
+func (b *circuitBreakers) transition(group string, c *circuit, to CircuitState, err error) *CircuitStateChange {
+	if c.state == to {
+		return nil
+	}
+	change := &CircuitStateChange{Group: group, From: c.state, To: to, Err: err}
+	c.state = to
+	c.probes = 0
+	c.generation++
+	if to == CircuitOpen {
+		c.openedAt = time.Now()
+	}
+	if to == CircuitClosed {
+		c.failures = 0
+	}
+	return change
+}
+
+// notify reports a state change to the hook, outside of the lock
+func (b *circuitBreakers) notify(change *CircuitStateChange) {
+	if change != nil && b.options.OnStateChange != nil {
+		b.options.OnStateChange(*change)
+	}
+}
@@ -0,0 +1,130 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests that the circuit opens on consecutive 5xx failures,
+// fails fast while open and closes again after a successful probe
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"code":200,"msg":"success","data":{}}`))
+	}))
+	defer server.Close()
+
+	var changes []CircuitStateChange
+	client := NewClient("id", "secret",
+		WithBaseURL(server.URL),
+		WithCircuitBreaker(CircuitBreakerOptions{
+			FailureThreshold: 2,
+			OpenTimeout:      20 * time.Millisecond,
+			OnStateChange:    func(c CircuitStateChange) { changes = append(changes, c) },
+		}),
+	)
+	ctx := context.Background()
+	req := &VASPRequest{ChainID: ChainETH, Address: "0x1"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.VASPQuery(ctx, req); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Circuit opened too early on call %d", i)
+		}
+	}
+
+	_, err := client.VASPQuery(ctx, req)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) || openErr.Group != EndpointGroupCompliance {
+		t.Fatalf("Expected open circuit error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("Expected 2 HTTP calls, got %d", n)
+	}
+
+	// Other endpoint groups are not affected
+	if _, err := client.V4EOAAddressRiskAssessment(ctx, &AddressRiskRequest{ChainID: ChainETH, Address: "0x1"}); errors.Is(err, ErrCircuitOpen) {
+		t.Error("Expected the V4 circuit to be closed")
+	}
+
+	healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+	if _, err := client.VASPQuery(ctx, req); err != nil {
+		t.Fatalf("Expected probe to succeed, got %v", err)
+	}
+
+	var transitions []CircuitState
+	for _, c := range changes {
+		if c.Group == EndpointGroupCompliance {
+			transitions = append(transitions, c.To)
+		}
+	}
+	expected := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("Expected transitions %v, got %v", expected, transitions)
+		}
+	}
+}
+
+// TestCircuitBreakerOutcomes tests that deadlines count as failures, cancellations are
+// ignored and probes of an earlier half-open state are not released twice
+func TestCircuitBreakerOutcomes(t *testing.T) {
+	breakers := newCircuitBreakers(CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Millisecond, HalfOpenMaxRequests: 2})
+	endpoint := EndpointVASP
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	record, err := breakers.allow(endpoint)
+	if err != nil {
+		t.Fatalf("Expected a closed circuit, got %v", err)
+	}
+	record(canceled, context.Canceled)
+	if _, err := breakers.allow(endpoint); err != nil {
+		t.Fatalf("Expected a canceled request to be ignored, got %v", err)
+	}
+
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	record, _ = breakers.allow(endpoint)
+	record(expired, context.DeadlineExceeded)
+	if _, err := breakers.allow(endpoint); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected a deadline to open the circuit, got %v", err)
+	}
+
+	// Two probes: the first reopens the circuit, the second must not free a
+	// probe slot of the next half-open state
+	time.Sleep(2 * time.Millisecond)
+	first, err := breakers.allow(endpoint)
+	if err != nil {
+		t.Fatalf("Expected a probe, got %v", err)
+	}
+	second, err := breakers.allow(endpoint)
+	if err != nil {
+		t.Fatalf("Expected a second probe, got %v", err)
+	}
+	first(context.Background(), errors.New("down"))
+	time.Sleep(2 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if _, err := breakers.allow(endpoint); err != nil {
+			t.Fatalf("Expected probe %d, got %v", i+1, err)
+		}
+	}
+	second(canceled, context.Canceled)
+	if _, err := breakers.allow(endpoint); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the probe limit to hold after a stale release, got %v", err)
+	}
+}
@@ -0,0 +1,467 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCreditCost is the cost of a call to an endpoint missing from the cost table
+	DefaultCreditCost = 1
+
+	// DefaultBudgetReconcileInterval is the default interval between balance reconciliations
+	DefaultBudgetReconcileInterval = 5 * time.Minute
+
+	// DefaultBudgetEquityEndWarning is the default time before the equity end date an alert is fired
+	DefaultBudgetEquityEndWarning = 7 * 24 * time.Hour
+)
+
+// ErrBudgetExceeded is matched by errors returned when a call would exceed a hard credit limit
+var ErrBudgetExceeded = errors.New("beosin: credit budget exceeded")
+
+// BudgetExceededError is returned when a call is rejected by a hard credit limit
+type BudgetExceededError struct {
+	// Tenant is the tenant whose limit was reached, or empty for the daily limit
+	Tenant string
+
+	// Used is the number of credits used today, including calls in flight
+	Used int64
+
+	// Cost is the cost of the rejected call
+	Cost int64
+
+	// Limit is the hard limit
+	Limit int64
+}
+
+// Error implements the error interface
+func (e *BudgetExceededError) Error() string {
+	if e.Tenant == "" {
+		return fmt.Sprintf("beosin: credit budget exceeded: used=%d, cost=%d, daily limit=%d", e.Used, e.Cost, e.Limit)
+	}
+	return fmt.Sprintf("beosin: credit budget exceeded: tenant=%s, used=%d, cost=%d, limit=%d", e.Tenant, e.Used, e.Cost, e.Limit)
+}
+
+// Is reports whether target is ErrBudgetExceeded
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// BudgetLimit is a daily credit limit
+type BudgetLimit struct {
+	// Soft fires a BudgetSoftLimit alert once the credits used today reach it (0 disables it)
+	Soft int64
+
+	// Hard rejects calls that would use more credits today (0 disables it)
+	Hard int64
+}
+
+// BudgetAlertKind is the kind of a budget alert
+type BudgetAlertKind int
+
+const (
+	// BudgetSoftLimit is fired when a soft limit is reached
+	BudgetSoftLimit BudgetAlertKind = iota
+
+	// BudgetLowBalance is fired when the remaining credits drop below a threshold
+	BudgetLowBalance
+
+	// BudgetEquityEnding is fired when the equity end date approaches
+	BudgetEquityEnding
+)
+
+// String returns the name of the alert kind
+func (k BudgetAlertKind) String() string {
+	switch k {
+	case BudgetSoftLimit:
+		return "soft-limit"
+	case BudgetLowBalance:
+		return "low-balance"
+	case BudgetEquityEnding:
+		return "equity-ending"
+	}
+	return "unknown"
+}
+
+// BudgetAlert describes a budget alert
+type BudgetAlert struct {
+	// Kind is the kind of the alert
+	Kind BudgetAlertKind
+
+	// Tenant is the tenant whose soft limit was reached, or empty for the daily limit
+	Tenant string
+
+	// Used is the number of credits used today by the tenant or in total
+	Used int64
+
+	// Threshold is the soft limit or balance threshold that was crossed
+	Threshold int64
+
+	// Balance is the estimated number of remaining credits
+	Balance int64
+
+	// EquityEnd is the end of the equity period
+	EquityEnd time.Time
+}
+
+// BudgetUsage is a snapshot of the credit usage
+type BudgetUsage struct {
+	// Day is the start of the current accounting day
+	Day time.Time
+
+	// Used is the number of credits used today
+	Used int64
+
+	// Tenants is the number of credits used today per tenant
+	Tenants map[string]int64
+
+	// Balance is the last reconciled balance minus the credits used since
+	Balance int64
+
+	// BalanceUpdated is when the balance was last reconciled (zero if never)
+	BalanceUpdated time.Time
+
+	// EquityEnd is the end of the equity period, if known
+	EquityEnd time.Time
+}
+
+// BudgetOptions configures a budget
+type BudgetOptions struct {
+	// Costs is the number of credits charged per endpoint (defaults to DefaultCreditCosts).
+	// Endpoints missing from the table cost DefaultCreditCost.
+	Costs map[string]int64
+
+	// Daily limits the credits used per day across all tenants
+	Daily BudgetLimit
+
+	// Tenants limits the credits used per day by individual tenants
+	Tenants map[string]BudgetLimit
+
+	// TenantDefault limits the credits used per day by tenants missing from Tenants
+	TenantDefault BudgetLimit
+
+	// LowBalanceThresholds fire a BudgetLowBalance alert when the remaining credits drop below them
+	LowBalanceThresholds []int64
+
+	// EquityEndWarning is how long before the equity end date a BudgetEquityEnding alert is fired
+	EquityEndWarning time.Duration
+
+	// ReconcileInterval is how often Run reconciles the balance with GetAccountBalance
+	ReconcileInterval time.Duration
+
+	// Location defines the day boundaries of the daily limits (defaults to UTC)
+	Location *time.Location
+
+	// OnAlert is called when a soft limit, balance threshold or equity warning is reached
+	OnAlert func(BudgetAlert)
+}
+
+// DefaultCreditCosts returns the default cost table: one credit per call, balance queries are free
+func DefaultCreditCosts() map[string]int64 {
+	return map[string]int64{
+		EndpointAccountBalance:   0,
+		EndpointDeposit:          1,
+		EndpointWithdraw:         1,
+		EndpointAddressRisk:      1,
+		EndpointMaliciousAddress: 1,
+		EndpointVASP:             1,
+		EndpointV4AddressRisk:    1,
+		EndpointV4Deposit:        1,
+		EndpointV4Withdraw:       1,
+		EndpointBlackScreening:   1,
+	}
+}
+
+// applyDefaults fills unset options with default values
+func (o *BudgetOptions) applyDefaults() {
+	if o.Costs == nil {
+		o.Costs = DefaultCreditCosts()
+	}
+	if o.EquityEndWarning <= 0 {
+		o.EquityEndWarning = DefaultBudgetEquityEndWarning
+	}
+	if o.ReconcileInterval <= 0 {
+		o.ReconcileInterval = DefaultBudgetReconcileInterval
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+}
+
+// tenantContextKey is the context key of the tenant
+type tenantContextKey struct{}
+
+// ContextWithTenant returns a context whose calls are accounted to the tenant
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by ContextWithTenant, or an empty string
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// budgetUsage is the usage of the day or of one tenant
+type budgetUsage struct {
+	used      int64
+	reserved  int64
+	softFired bool
+}
+
+// reservation is the cost of a call in flight
+type reservation struct {
+	day    time.Time
+	tenant string
+	cost   int64
+}
+
+// Budget tracks the credits used by Client calls, enforces daily limits and
+// fires alerts on low balance. Calls are charged when the API answered them
+// successfully, but not for error codes such as 41038 while an assessment is
+// still running, nor when they were served from the cache or shared by a
+// concurrent identical call.
+type Budget struct {
+	options BudgetOptions
+
+	mu      sync.Mutex
+	day     time.Time
+	total   budgetUsage
+	tenants map[string]*budgetUsage
+
+	balance        int64
+	spent          int64
+	balanceUpdated time.Time
+	equityEnd      time.Time
+	lowFired       map[int64]bool
+	equityFired    bool
+}
+
+// NewBudget creates a budget with the given options
+func NewBudget(options BudgetOptions) *Budget {
+	options.applyDefaults()
+	return &Budget{
+		options:  options,
+		tenants:  make(map[string]*budgetUsage),
+		lowFired: make(map[int64]bool),
+	}
+}
+
+// interceptor returns the interceptor that enforces the budget, installed by WithBudget
+func (b *Budget) interceptor() Interceptor {
+	return func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+		res, err := b.reserve(TenantFromContext(ctx), b.cost(info.Endpoint))
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next(ctx, req)
+		if balance, ok := resp.(*AccountBalanceResponse); ok && err == nil && balance.Data != nil {
+			b.observeBalance(balance.Data)
+		}
+
+		charged := info.StatusCode == http.StatusOK && info.Code == 200 && !info.CacheHit && !info.Shared
+		b.settle(res, charged)
+		return resp, err
+	}
+}
+
+// Reconcile queries the account balance and resets the balance estimate
+func (b *Budget) Reconcile(ctx context.Context, c Client) error {
+	resp, err := c.GetAccountBalance(ctx)
+	if err != nil {
+		return err
+	}
+	if resp.Data != nil {
+		b.observeBalance(resp.Data)
+	}
+	return nil
+}
+
+// Run reconciles the balance every ReconcileInterval until the context is done.
+// Failed reconciliations are retried at the next interval.
+func (b *Budget) Run(ctx context.Context, c Client) error {
+	ticker := time.NewTicker(b.options.ReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		_ = b.Reconcile(ctx, c)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Usage returns a snapshot of the credit usage
+func (b *Budget) Usage() BudgetUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollover()
+
+	usage := BudgetUsage{
+		Day:            b.day,
+		Used:           b.total.used,
+		Tenants:        make(map[string]int64, len(b.tenants)),
+		Balance:        b.balance - b.spent,
+		BalanceUpdated: b.balanceUpdated,
+		EquityEnd:      b.equityEnd,
+	}
+	for tenant, u := range b.tenants {
+		if tenant != "" {
+			usage.Tenants[tenant] = u.used
+		}
+	}
+	return usage
+}
+
+// cost returns the number of credits charged for a call to the endpoint
+func (b *Budget) cost(endpoint string) int64 {
+	if cost, ok := b.options.Costs[endpoint]; ok {
+		return cost
+	}
+	return DefaultCreditCost
+}
+
+// tenantLimit returns the limit of a tenant
+func (b *Budget) tenantLimit(tenant string) BudgetLimit {
+	if limit, ok := b.options.Tenants[tenant]; ok {
+		return limit
+	}
+	return b.options.TenantDefault
+}
+
+// rollover resets the usage when a new day has started; the caller must hold the lock
+func (b *Budget) rollover() {
+	now := time.Now().In(b.options.Location)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, b.options.Location)
+	if !day.Equal(b.day) {
+		b.day = day
+		b.total = budgetUsage{}
+		b.tenants = make(map[string]*budgetUsage)
+	}
+}
+
+// reserve checks the hard limits and reserves the cost of a call until it is settled
+func (b *Budget) reserve(tenant string, cost int64) (reservation, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollover()
+
+	res := reservation{day: b.day, tenant: tenant, cost: cost}
+	if cost <= 0 {
+		return res, nil
+	}
+
+	if hard := b.options.Daily.Hard; hard > 0 && b.total.used+b.total.reserved+cost > hard {
+		return res, &BudgetExceededError{Used: b.total.used + b.total.reserved, Cost: cost, Limit: hard}
+	}
+	usage := b.tenantUsage(tenant)
+	if hard := b.tenantLimit(tenant).Hard; tenant != "" && hard > 0 && usage.used+usage.reserved+cost > hard {
+		return res, &BudgetExceededError{Tenant: tenant, Used: usage.used + usage.reserved, Cost: cost, Limit: hard}
+	}
+
+	b.total.reserved += cost
+	usage.reserved += cost
+	return res, nil
+}
+
+// tenantUsage returns the usage of a tenant; the caller must hold the lock
+func (b *Budget) tenantUsage(tenant string) *budgetUsage {
+	usage, ok := b.tenants[tenant]
+	if !ok {
+		usage = &budgetUsage{}
+		b.tenants[tenant] = usage
+	}
+	return usage
+}
+
+// settle releases the reservation of a call and charges its cost if the API answered it
+func (b *Budget) settle(res reservation, charged bool) {
+	if res.cost <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	b.rollover()
+	usage := b.tenantUsage(res.tenant)
+	if res.day.Equal(b.day) {
+		b.total.reserved -= res.cost
+		usage.reserved -= res.cost
+	}
+
+	var alerts []BudgetAlert
+	if charged {
+		b.total.used += res.cost
+		usage.used += res.cost
+		b.spent += res.cost
+
+		if soft := b.options.Daily.Soft; soft > 0 && b.total.used >= soft && !b.total.softFired {
+			b.total.softFired = true
+			alerts = append(alerts, BudgetAlert{Kind: BudgetSoftLimit, Used: b.total.used, Threshold: soft, Balance: b.balance - b.spent})
+		}
+		if soft := b.tenantLimit(res.tenant).Soft; res.tenant != "" && soft > 0 && usage.used >= soft && !usage.softFired {
+			usage.softFired = true
+			alerts = append(alerts, BudgetAlert{Kind: BudgetSoftLimit, Tenant: res.tenant, Used: usage.used, Threshold: soft, Balance: b.balance - b.spent})
+		}
+		alerts = append(alerts, b.balanceAlerts()...)
+	}
+	b.mu.Unlock()
+	b.notify(alerts)
+}
+
+// observeBalance resets the balance estimate to the balance reported by the API
+func (b *Budget) observeBalance(data *AccountBalanceData) {
+	b.mu.Lock()
+	b.balance = data.SurplusIntegral
+	b.spent = 0
+	b.balanceUpdated = time.Now()
+	if end := unixTime(data.EquityEndDate); !end.Equal(b.equityEnd) {
+		b.equityEnd = end
+		b.equityFired = false
+	}
+	alerts := b.balanceAlerts()
+	b.mu.Unlock()
+	b.notify(alerts)
+}
+
+// balanceAlerts returns the balance and equity alerts that have become due; the caller must hold the lock
+func (b *Budget) balanceAlerts() []BudgetAlert {
+	if b.balanceUpdated.IsZero() {
+		return nil
+	}
+
+	var alerts []BudgetAlert
+	balance := b.balance - b.spent
+	for _, threshold := range b.options.LowBalanceThresholds {
+		switch {
+		case balance < threshold && !b.lowFired[threshold]:
+			b.lowFired[threshold] = true
+			alerts = append(alerts, BudgetAlert{Kind: BudgetLowBalance, Threshold: threshold, Balance: balance, EquityEnd: b.equityEnd})
+		case balance >= threshold:
+			// The account was topped up; fire again the next time the balance drops
+			b.lowFired[threshold] = false
+		}
+	}
+	if !b.equityEnd.IsZero() && !b.equityFired && time.Until(b.equityEnd) <= b.options.EquityEndWarning {
+		b.equityFired = true
+		alerts = append(alerts, BudgetAlert{Kind: BudgetEquityEnding, Balance: balance, EquityEnd: b.equityEnd})
+	}
+	return alerts
+}
+
+// notify reports alerts to the hook, outside of the lock
+func (b *Budget) notify(alerts []BudgetAlert) {
+	if b.options.OnAlert == nil {
+		return
+	}
+	for _, alert := range alerts {
+		b.options.OnAlert(alert)
+	}
+}
@@ -0,0 +1,133 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestBudgetLimits tests that hard limits reject calls before they are sent, soft limits fire
+// alerts and cache hits are not charged
+func TestBudgetLimits(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"isVasp":false}}`))
+	}))
+	defer server.Close()
+
+	var alerts []BudgetAlert
+	budget := NewBudget(BudgetOptions{
+		Daily:         BudgetLimit{Soft: 2, Hard: 4},
+		TenantDefault: BudgetLimit{Hard: 2},
+		OnAlert:       func(a BudgetAlert) { alerts = append(alerts, a) },
+	})
+	client := NewClient("id", "secret",
+		WithBaseURL(server.URL),
+		WithBudget(budget),
+		WithCache(NewMemoryCache(10), DefaultCacheOptions()),
+	)
+
+	query := func(ctx context.Context, address string) error {
+		_, err := client.VASPQuery(ctx, &VASPRequest{ChainID: ChainETH, Address: address})
+		return err
+	}
+
+	acme := ContextWithTenant(context.Background(), "acme")
+	for _, address := range []string{"0x1", "0x1", "0x2"} {
+		if err := query(acme, address); err != nil {
+			t.Fatalf("Query of %s failed: %v", address, err)
+		}
+	}
+	err := query(acme, "0x3")
+	var budgetErr *BudgetExceededError
+	if !errors.As(err, &budgetErr) || !errors.Is(err, ErrBudgetExceeded) || budgetErr.Tenant != "acme" {
+		t.Fatalf("Expected tenant budget error, got %v", err)
+	}
+
+	ctx := context.Background()
+	if err := query(ctx, "0x4"); err != nil {
+		t.Fatalf("Query without tenant failed: %v", err)
+	}
+	if err := query(ctx, "0x5"); err != nil {
+		t.Fatalf("Query without tenant failed: %v", err)
+	}
+	if err := query(ctx, "0x6"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Expected daily budget error, got %v", err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 4 {
+		t.Errorf("Expected 4 HTTP calls, got %d", n)
+	}
+	usage := budget.Usage()
+	if usage.Used != 4 || usage.Tenants["acme"] != 2 {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+	if len(alerts) != 1 || alerts[0].Kind != BudgetSoftLimit || alerts[0].Used != 2 {
+		t.Errorf("Expected one soft limit alert, got %+v", alerts)
+	}
+}
+
+// TestBudgetChargesSuccessOnly tests that responses with an API error code are not charged
+func TestBudgetChargesSuccessOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":%d,"msg":"task executing"}`, ErrCodeTaskExecuting)
+	}))
+	defer server.Close()
+
+	budget := NewBudget(BudgetOptions{})
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithBudget(budget))
+	req := &DepositRequest{ChainID: ChainETH, Hash: "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"}
+	for i := 0; i < 3; i++ {
+		if _, err := client.DepositTransactionAssessment(context.Background(), req); !errors.Is(err, ErrTaskExecuting) {
+			t.Fatalf("Expected the task executing error, got %v", err)
+		}
+	}
+	if used := budget.Usage().Used; used != 0 {
+		t.Errorf("Expected no credits to be charged for pending results, got %d", used)
+	}
+}
+
+// TestBudgetReconcile tests the balance estimate and the low balance and equity alerts
+func TestBudgetReconcile(t *testing.T) {
+	equityEnd := time.Now().Add(48 * time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointAccountBalance {
+			fmt.Fprintf(w, `{"code":200,"msg":"success","data":{"surplusIntegral":101,"equityEndDate":%d}}`, equityEnd)
+			return
+		}
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"isVasp":false}}`))
+	}))
+	defer server.Close()
+
+	var alerts []BudgetAlert
+	budget := NewBudget(BudgetOptions{
+		LowBalanceThresholds: []int64{100, 50},
+		OnAlert:              func(a BudgetAlert) { alerts = append(alerts, a) },
+	})
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithBudget(budget))
+	ctx := context.Background()
+
+	if err := budget.Reconcile(ctx, client); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].Kind != BudgetEquityEnding {
+		t.Fatalf("Expected an equity ending alert, got %+v", alerts)
+	}
+
+	for i := 0; i < 2; i++ {
+		client.VASPQuery(ctx, &VASPRequest{ChainID: ChainETH, Address: "0x1"})
+	}
+	if len(alerts) != 2 || alerts[1].Kind != BudgetLowBalance || alerts[1].Threshold != 100 || alerts[1].Balance != 99 {
+		t.Errorf("Expected one low balance alert, got %+v", alerts)
+	}
+
+	if usage := budget.Usage(); usage.Balance != 99 || usage.EquityEnd.Unix() != equityEnd {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+}
@@ -0,0 +1,311 @@
+package beosin
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultAddressCacheTTL is the default TTL for address-level query results
+	DefaultAddressCacheTTL = 10 * time.Minute
+
+	// DefaultTransactionCacheTTL is the default TTL for final transaction assessment results
+	DefaultTransactionCacheTTL = 24 * time.Hour
+
+	// DefaultNegativeCacheTTL is the default TTL for cached error responses
+	DefaultNegativeCacheTTL = time.Minute
+)
+
+// Cache stores raw API response bodies.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for key, if present and not expired
+	Get(ctx context.Context, key string) ([]byte, bool)
+
+	// Set stores a value for key for the given duration
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+}
+
+// CacheOptions configures which responses are cached and for how long
+type CacheOptions struct {
+	// TTL is the cache duration per endpoint; endpoints without a TTL are not cached
+	TTL map[string]time.Duration
+
+	// NegativeTTL is the cache duration for responses with one of NegativeCodes (0 disables negative caching)
+	NegativeTTL time.Duration
+
+	// NegativeCodes are the API error codes whose responses are cached
+	NegativeCodes []int
+}
+
+// DefaultCacheOptions returns cache options covering the address-level queries and
+// final transaction assessments, with negative caching of invalid input errors.
+// Responses reporting that a task is still executing are never cached.
+func DefaultCacheOptions() CacheOptions {
+	return CacheOptions{
+		TTL: map[string]time.Duration{
+			EndpointAddressRisk:      DefaultAddressCacheTTL,
+			EndpointV4AddressRisk:    DefaultAddressCacheTTL,
+			EndpointMaliciousAddress: DefaultAddressCacheTTL,
+			EndpointVASP:             DefaultAddressCacheTTL,
+			EndpointBlackScreening:   DefaultAddressCacheTTL,
+			EndpointDeposit:          DefaultTransactionCacheTTL,
+			EndpointWithdraw:         DefaultTransactionCacheTTL,
+			EndpointV4Deposit:        DefaultTransactionCacheTTL,
+			EndpointV4Withdraw:       DefaultTransactionCacheTTL,
+		},
+		NegativeTTL:   DefaultNegativeCacheTTL,
+		NegativeCodes: []int{ErrCodeAddressError, ErrCodeTxHashError, ErrCodePlatformNotSupported},
+	}
+}
+
+// ttlFor returns how long a response with the given API code may be cached
+func (o *CacheOptions) ttlFor(endpoint string, code int) time.Duration {
+	if code == ErrCodeTaskExecuting {
+		return 0
+	}
+	if code == 200 {
+		return o.TTL[endpoint]
+	}
+	for _, negative := range o.NegativeCodes {
+		if negative == code {
+			return o.NegativeTTL
+		}
+	}
+	return 0
+}
+
+// cacheKey builds a normalized cache key from the endpoint and query parameters
+func cacheKey(endpoint string, params url.Values) string {
+	normalized := url.Values{}
+	for key, values := range params {
+		for _, value := range values {
+			normalized.Add(key, normalizeCacheValue(value))
+		}
+	}
+	return endpoint + "?" + normalized.Encode()
+}
+
+// normalizeCacheValue trims a parameter and lowercases case-insensitive hex values
+func normalizeCacheValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 2 && (value[:2] == "0x" || value[:2] == "0X") && isHex(value[2:]) {
+		return strings.ToLower(value)
+	}
+	return value
+}
+
+// isHex checks if a string consists only of hexadecimal digits
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// CacheStats holds cache hit and miss counters
+type CacheStats struct {
+	// Hits is the number of lookups that found a value
+	Hits uint64
+
+	// Misses is the number of lookups that found no value
+	Misses uint64
+
+	// Evictions is the number of values removed to make room for new ones
+	Evictions uint64
+}
+
+// cacheCounters tracks cache statistics
+type cacheCounters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// record counts a lookup
+func (c *cacheCounters) record(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// stats returns a snapshot of the counters
+func (c *cacheCounters) stats() CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// memoryCacheEntry is an element of the memory cache
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in-memory LRU cache with per-entry TTL
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+	counters cacheCounters
+}
+
+// NewMemoryCache creates an in-memory LRU cache holding up to capacity entries
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache
+func (m *MemoryCache) Get(_ context.Context, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if ok && time.Now().After(elem.Value.(*memoryCacheEntry).expires) {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+		ok = false
+	}
+	m.counters.record(ok)
+	if !ok {
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheEntry).value, true
+}
+
+// Set implements Cache
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if elem, ok := m.entries[key]; ok {
+		elem.Value = entry
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+		m.counters.evictions.Add(1)
+	}
+}
+
+// Len returns the number of cached entries, including expired ones not yet removed
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// Stats returns the hit, miss and eviction counters
+func (m *MemoryCache) Stats() CacheStats {
+	return m.counters.stats()
+}
+
+// fileCacheEntry is the on-disk format of a file cache entry
+type fileCacheEntry struct {
+	Expires time.Time       `json:"expires"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// FileCache is a cache storing one file per entry in a directory.
+// It survives process restarts and can be shared by processes on the same host.
+type FileCache struct {
+	dir      string
+	counters cacheCounters
+}
+
+// NewFileCache creates a file-backed cache in dir, creating the directory if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// path returns the file path of a cache key
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements Cache
+func (f *FileCache) Get(_ context.Context, key string) ([]byte, bool) {
+	data, err := os.ReadFile(f.path(key))
+	if err != nil {
+		f.counters.record(false)
+		return nil, false
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || time.Now().After(entry.Expires) {
+		_ = os.Remove(f.path(key))
+		f.counters.record(false)
+		return nil, false
+	}
+
+	f.counters.record(true)
+	return entry.Value, true
+}
+
+// Set implements Cache
+func (f *FileCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) {
+	data, err := json.Marshal(fileCacheEntry{Expires: time.Now().Add(ttl), Value: value})
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that readers never see a partial entry
+	tmp, err := os.CreateTemp(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// Stats returns the hit and miss counters
+func (f *FileCache) Stats() CacheStats {
+	return f.counters.stats()
+}
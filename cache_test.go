@@ -0,0 +1,83 @@
+package beosin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMemoryCacheLRU tests eviction of the least recently used entry and expiry
+func TestMemoryCacheLRU(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+
+	cache.Set(ctx, "a", []byte("1"), time.Minute)
+	cache.Set(ctx, "b", []byte("2"), time.Minute)
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok := cache.Get(ctx, "b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if _, ok := cache.Get(ctx, "a"); !ok {
+		t.Error("Expected a to be cached")
+	}
+
+	cache.Set(ctx, "d", []byte("4"), -time.Second)
+	if _, ok := cache.Get(ctx, "d"); ok {
+		t.Error("Expected d to be expired")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 2 || stats.Evictions != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+// TestClientCache tests caching of final results, negative caching and key normalization
+func TestClientCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch r.URL.Query().Get("address") {
+		case "bad":
+			fmt.Fprint(w, `{"code":40022,"msg":"address error"}`)
+		case "pending":
+			fmt.Fprint(w, `{"code":41038,"msg":"task executing"}`)
+		default:
+			fmt.Fprint(w, `{"code":200,"msg":"success","data":{"isVasp":true}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret",
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(100), DefaultCacheOptions()),
+	)
+	ctx := context.Background()
+	query := func(address string) {
+		_, _ = client.VASPQuery(ctx, &VASPRequest{ChainID: ChainETH, Address: address})
+	}
+
+	query("0xEC6ad3cb0e62cd7c8e75d2919f12c3195d998002")
+	query("0xec6ad3cb0e62cd7c8e75d2919f12c3195d998002")
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected normalized addresses to share a cache entry, got %d calls", n)
+	}
+
+	query("bad")
+	query("bad")
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("Expected address errors to be cached, got %d calls", n)
+	}
+
+	query("pending")
+	query("pending")
+	if n := atomic.LoadInt32(&calls); n != 4 {
+		t.Errorf("Expected executing tasks not to be cached, got %d calls", n)
+	}
+}
@@ -0,0 +1,212 @@
+package beosin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ChainFamily is the address format family of a chain
+type ChainFamily string
+
+// Address families
+const (
+	FamilyEVM    ChainFamily = "evm"
+	FamilyUTXO   ChainFamily = "utxo"
+	FamilyTron   ChainFamily = "tron"
+	FamilySolana ChainFamily = "solana"
+	FamilyTON    ChainFamily = "ton"
+	FamilyXRP    ChainFamily = "xrp"
+	FamilyAptos  ChainFamily = "aptos"
+	FamilySui    ChainFamily = "sui"
+
+	// FamilyNeo addresses are base58 encoded and are not validated locally
+	FamilyNeo ChainFamily = "neo"
+)
+
+// ChainTier is the Beosin query tier of a chain
+type ChainTier int
+
+const (
+	// ChainTierFull chains support address and transaction assessments
+	ChainTierFull ChainTier = iota
+
+	// ChainTierBasic chains support address assessments only
+	ChainTierBasic
+)
+
+// String returns the name of the tier
+func (t ChainTier) String() string {
+	switch t {
+	case ChainTierFull:
+		return "full"
+	case ChainTierBasic:
+		return "basic"
+	}
+	return "unknown"
+}
+
+// Chain describes a chain supported by the API
+type Chain struct {
+	// ID is the chain ID sent to the API (e.g. ChainETH)
+	ID string
+
+	// Name is the display name
+	Name string
+
+	// Aliases are additional names the chain can be looked up by
+	Aliases []string
+
+	// Family is the address format family
+	Family ChainFamily
+
+	// NativeToken is the symbol of the native token
+	NativeToken string
+
+	// Tier is the Beosin query tier, following the Full Query and Basic Query
+	// grouping of the chain ID constants. Endpoints derives the accepted endpoints
+	// from it.
+	Tier ChainTier
+
+	// Platform is the platform name for black address screening. It is only set
+	// for the names documented on BlackScreeningRequest (bsc and eth); other
+	// platforms can still be passed to BlackAddressScreening directly.
+	Platform string
+}
+
+// Endpoints returns the endpoints that accept the chain. The API documentation has
+// no per-endpoint chain table, so this assumes that every chain supports the address
+// endpoints and that only Full Query chains support the transaction endpoints. A chain
+// can still be passed to an endpoint this does not list.
+func (c Chain) Endpoints() []string {
+	endpoints := []string{EndpointAddressRisk, EndpointMaliciousAddress, EndpointVASP, EndpointV4AddressRisk}
+	if c.Tier == ChainTierFull {
+		endpoints = append(endpoints, EndpointDeposit, EndpointWithdraw, EndpointV4Deposit, EndpointV4Withdraw)
+	}
+	if c.Platform != "" {
+		endpoints = append(endpoints, EndpointBlackScreening)
+	}
+	return endpoints
+}
+
+// Supports checks if the endpoint accepts the chain
+func (c Chain) Supports(endpoint string) bool {
+	return slices.Contains(c.Endpoints(), endpoint)
+}
+
+// chains is the registry of supported chains in documentation order
+var chains = []Chain{
+	// Full Query supported chains
+	{ID: ChainBTC, Name: "Bitcoin", Aliases: []string{"btc", "bitcoin"}, Family: FamilyUTXO, NativeToken: "BTC", Tier: ChainTierFull},
+	{ID: ChainETH, Name: "Ethereum", Aliases: []string{"eth", "ethereum"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierFull, Platform: "eth"},
+	{ID: ChainOptimism, Name: "Optimism", Aliases: []string{"op", "optimism"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierFull},
+	{ID: ChainBSC, Name: "BNB Smart Chain", Aliases: []string{"bsc", "bnb", "binance"}, Family: FamilyEVM, NativeToken: "BNB", Tier: ChainTierFull, Platform: "bsc"},
+	{ID: ChainTron, Name: "Tron", Aliases: []string{"trx", "tron"}, Family: FamilyTron, NativeToken: "TRX", Tier: ChainTierFull},
+	{ID: ChainPolygon, Name: "Polygon", Aliases: []string{"polygon", "matic", "pol"}, Family: FamilyEVM, NativeToken: "POL", Tier: ChainTierFull},
+	{ID: ChainHsk, Name: "HashKey Chain", Aliases: []string{"hsk", "hashkey"}, Family: FamilyEVM, NativeToken: "HSK", Tier: ChainTierFull},
+	{ID: ChainLTC, Name: "Litecoin", Aliases: []string{"ltc", "litecoin"}, Family: FamilyUTXO, NativeToken: "LTC", Tier: ChainTierFull},
+	{ID: ChainZksync, Name: "zkSync Era", Aliases: []string{"zksync", "zksync-era"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierFull},
+	{ID: ChainIoTeX, Name: "IoTeX", Aliases: []string{"iotex", "iotx"}, Family: FamilyEVM, NativeToken: "IOTX", Tier: ChainTierFull},
+	{ID: ChainKaia, Name: "Kaia", Aliases: []string{"kaia", "klaytn"}, Family: FamilyEVM, NativeToken: "KAIA", Tier: ChainTierFull},
+	{ID: ChainArbitrum, Name: "Arbitrum One", Aliases: []string{"arb", "arbitrum"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierFull},
+	{ID: ChainAvalanche, Name: "Avalanche C-Chain", Aliases: []string{"avax", "avalanche"}, Family: FamilyEVM, NativeToken: "AVAX", Tier: ChainTierFull},
+	{ID: ChainAptos, Name: "Aptos", Aliases: []string{"apt"}, Family: FamilyAptos, NativeToken: "APT", Tier: ChainTierFull},
+	{ID: ChainSolana, Name: "Solana", Aliases: []string{"sol"}, Family: FamilySolana, NativeToken: "SOL", Tier: ChainTierFull},
+	{ID: ChainTON, Name: "TON", Aliases: []string{"toncoin"}, Family: FamilyTON, NativeToken: "TON", Tier: ChainTierFull},
+	{ID: ChainXRP, Name: "XRP Ledger", Aliases: []string{"ripple", "xrpl"}, Family: FamilyXRP, NativeToken: "XRP", Tier: ChainTierFull},
+
+	// Basic Query supported chains
+	{ID: ChainBase, Name: "Base", Aliases: []string{"base"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierBasic},
+	{ID: ChainLinea, Name: "Linea", Aliases: []string{"linea"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierBasic},
+	{ID: ChainScroll, Name: "Scroll", Aliases: []string{"scroll"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierBasic},
+	{ID: ChainMerlin, Name: "Merlin Chain", Aliases: []string{"merlin"}, Family: FamilyEVM, NativeToken: "BTC", Tier: ChainTierBasic},
+	{ID: ChainNeo, Name: "Neo", Aliases: []string{"neo"}, Family: FamilyNeo, NativeToken: "GAS", Tier: ChainTierBasic},
+	{ID: ChainZklink, Name: "zkLink Nova", Aliases: []string{"zklink"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierBasic},
+	{ID: ChainRonin, Name: "Ronin", Aliases: []string{"ronin", "ron"}, Family: FamilyEVM, NativeToken: "RON", Tier: ChainTierBasic},
+	{ID: ChainBerachain, Name: "Berachain", Aliases: []string{"berachain", "bera"}, Family: FamilyEVM, NativeToken: "BERA", Tier: ChainTierBasic},
+	{ID: ChainMonad, Name: "Monad", Aliases: []string{"mon"}, Family: FamilyEVM, NativeToken: "MON", Tier: ChainTierBasic},
+	{ID: ChainAstar, Name: "Astar", Aliases: []string{"astar", "astr"}, Family: FamilyEVM, NativeToken: "ASTR", Tier: ChainTierBasic},
+	{ID: ChainTaiko, Name: "Taiko", Aliases: []string{"taiko"}, Family: FamilyEVM, NativeToken: "ETH", Tier: ChainTierBasic},
+	{ID: ChainBitlayer, Name: "Bitlayer", Aliases: []string{"bitlayer"}, Family: FamilyEVM, NativeToken: "BTC", Tier: ChainTierBasic},
+	{ID: ChainSui, Name: "Sui", Family: FamilySui, NativeToken: "SUI", Tier: ChainTierBasic},
+	{ID: ChainSei, Name: "Sei", Aliases: []string{"sei"}, Family: FamilyEVM, NativeToken: "SEI", Tier: ChainTierBasic},
+	{ID: ChainKCC, Name: "KCC", Aliases: []string{"kcc", "kucoin"}, Family: FamilyEVM, NativeToken: "KCS", Tier: ChainTierBasic},
+	{ID: ChainSonic, Name: "Sonic", Aliases: []string{"sonic"}, Family: FamilyEVM, NativeToken: "S", Tier: ChainTierBasic},
+	{ID: ChainConfluxESpace, Name: "Conflux eSpace", Aliases: []string{"conflux", "cfx", "conflux-espace"}, Family: FamilyEVM, NativeToken: "CFX", Tier: ChainTierBasic},
+}
+
+var (
+	chainAliasesMu sync.RWMutex
+	chainAliases   = buildChainAliases(chains)
+)
+
+// buildChainAliases indexes the registry by ID, name and aliases. It panics if an
+// alias refers to two chains, so a mistake in the registry fails at init instead of
+// silently resolving to whichever chain comes last.
+func buildChainAliases(chains []Chain) map[string]int {
+	aliases := make(map[string]int)
+	for i, chain := range chains {
+		for _, alias := range append([]string{chain.ID, chain.Name}, chain.Aliases...) {
+			key := normalizeChainAlias(alias)
+			if existing, ok := aliases[key]; ok && existing != i {
+				panic(fmt.Sprintf("beosin: chain alias %q refers to both %s and %s", alias, chains[existing].ID, chain.ID))
+			}
+			aliases[key] = i
+		}
+	}
+	return aliases
+}
+
+// normalizeChainAlias makes alias lookups case-insensitive
+func normalizeChainAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
+// LookupChain returns the chain with the given ID, name or alias, ignoring case
+func LookupChain(alias string) (Chain, bool) {
+	chainAliasesMu.RLock()
+	i, ok := chainAliases[normalizeChainAlias(alias)]
+	chainAliasesMu.RUnlock()
+	if !ok {
+		return Chain{}, false
+	}
+	return cloneChain(chains[i]), true
+}
+
+// Chains returns all supported chains, Full Query chains first
+func Chains() []Chain {
+	result := make([]Chain, len(chains))
+	for i, chain := range chains {
+		result[i] = cloneChain(chain)
+	}
+	return result
+}
+
+// RegisterChainAlias adds an alias for a chain, e.g. an internal network name.
+// It fails if the chain is unknown or the alias already refers to another chain.
+func RegisterChainAlias(alias, chainID string) error {
+	key := normalizeChainAlias(alias)
+	if key == "" {
+		return fmt.Errorf("beosin: empty chain alias")
+	}
+
+	chainAliasesMu.Lock()
+	defer chainAliasesMu.Unlock()
+
+	i, ok := chainAliases[normalizeChainAlias(chainID)]
+	if !ok || chains[i].ID != chainID {
+		return fmt.Errorf("beosin: unknown chain ID %q", chainID)
+	}
+	if existing, ok := chainAliases[key]; ok && existing != i {
+		return fmt.Errorf("beosin: chain alias %q already refers to %s", alias, chains[existing].Name)
+	}
+	chainAliases[key] = i
+	return nil
+}
+
+// cloneChain copies a chain so callers cannot modify the registry
+func cloneChain(c Chain) Chain {
+	c.Aliases = slices.Clone(c.Aliases)
+	return c
+}
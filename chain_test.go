@@ -0,0 +1,51 @@
+package beosin
+
+import "testing"
+
+// TestChainRegistry tests alias lookups and that no alias refers to two chains
+func TestChainRegistry(t *testing.T) {
+	for _, chain := range Chains() {
+		for _, alias := range append([]string{chain.ID, chain.Name}, chain.Aliases...) {
+			if found, ok := LookupChain(alias); !ok || found.ID != chain.ID {
+				t.Errorf("Alias %q of %s resolves to %q", alias, chain.Name, found.ID)
+			}
+		}
+	}
+
+	eth, ok := LookupChain(" Ethereum ")
+	if !ok || eth.ID != ChainETH || eth.Family != FamilyEVM || eth.NativeToken != "ETH" {
+		t.Fatalf("Unexpected chain %+v", eth)
+	}
+	if base, _ := LookupChain("base"); base.Supports(EndpointV4Deposit) || !base.Supports(EndpointV4AddressRisk) {
+		t.Error("Expected Basic Query chains to support address endpoints only")
+	}
+
+	if tron, _ := LookupChain("tron"); tron.Platform != "" || tron.Supports(EndpointBlackScreening) {
+		t.Error("Expected no screening platform for chains without a documented name")
+	}
+	if address, err := ValidateAddress(ChainNeo, "NdtB8RXRmJ7Nhw1FPTm7E6HoDZGnDw37nf"); err != nil || address != "NdtB8RXRmJ7Nhw1FPTm7E6HoDZGnDw37nf" {
+		t.Errorf("Expected Neo addresses to be passed through, got %q, %v", address, err)
+	}
+
+	if err := RegisterChainAlias("ethereum-mainnet", ChainETH); err != nil {
+		t.Fatalf("RegisterChainAlias failed: %v", err)
+	}
+	if chain, ok := LookupChain("ETHEREUM-MAINNET"); !ok || chain.ID != ChainETH {
+		t.Errorf("Expected registered alias to resolve to Ethereum, got %+v", chain)
+	}
+	if err := RegisterChainAlias("bsc", ChainETH); err == nil {
+		t.Error("Expected an error for an alias of another chain")
+	}
+	if err := RegisterChainAlias("devnet", "12345"); err == nil {
+		t.Error("Expected an error for an unknown chain")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic for an alias of two chains in the registry")
+			}
+		}()
+		buildChainAliases([]Chain{{ID: ChainETH, Name: "Ethereum"}, {ID: ChainBSC, Name: "BNB Smart Chain", Aliases: []string{"ethereum"}}})
+	}()
+}
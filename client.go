@@ -3,12 +3,14 @@ package beosin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Client defines the interface for Beosin API operations
@@ -34,7 +36,11 @@ type Client interface {
 
 // client is the default implementation of the Client interface
 type client struct {
-	options *Options
+	options      *Options
+	limiters     *rateLimiters
+	interceptors []Interceptor
+	flights      *flightGroup
+	breakers     *circuitBreakers
 }
 
 // NewClient creates a new Beosin API client
@@ -50,54 +56,112 @@ func NewClient(appID, appSecret string, opts ...Option) Client {
 
 	options.applyDefaults()
 
-	return &client{
-		options: options,
+	// Built-in interceptors wrap the user's interceptors
+	var interceptors []Interceptor
+	if options.Metrics != nil {
+		interceptors = append(interceptors, metricsInterceptor(options.Metrics))
 	}
+	if options.Budget != nil {
+		interceptors = append(interceptors, options.Budget.interceptor())
+	}
+	interceptors = append(interceptors, options.Interceptors...)
+
+	c := &client{
+		options:      options,
+		limiters:     newRateLimiters(options),
+		interceptors: interceptors,
+	}
+	if options.CoalesceRequests {
+		c.flights = newFlightGroup()
+	}
+	if options.CircuitBreaker != nil {
+		c.breakers = newCircuitBreakers(*options.CircuitBreaker)
+	}
+	return c
 }
 
-// doRequest performs an HTTP GET request to the specified endpoint
-func (c *client) doRequest(ctx context.Context, endpoint string, params url.Values, result interface{}) error {
-	// Build the full URL
-	fullURL := c.options.BaseURL + endpoint
-	if len(params) > 0 {
-		fullURL += "?" + params.Encode()
+// invoke runs a Client call through the interceptor chain and performs the HTTP request
+func invoke[Resp, Req any](ctx context.Context, c *client, operation, endpoint string, req *Req, params func(*Req) url.Values) (*Resp, error) {
+	info := &CallInfo{
+		Operation: operation,
+		Endpoint:  endpoint,
+		ChainID:   requestChainID(req),
+		Header:    http.Header{},
+		Start:     time.Now(),
 	}
 
-	if c.options.Debug {
-		log.Printf("[BEOSIN DEBUG] Request: GET %s\n", fullURL)
+	handler := func(ctx context.Context, r interface{}) (interface{}, error) {
+		defer func() { info.Duration = time.Since(info.Start) }()
+
+		typedReq, ok := r.(*Req)
+		if !ok {
+			return nil, fmt.Errorf("invalid request type for %s: %T", operation, r)
+		}
+		if c.options.ValidateRequests && typedReq != nil {
+			// Normalize a copy so the caller's request is left untouched
+			copied := *typedReq
+			if n, ok := any(&copied).(requestNormalizer); ok {
+				if err := n.Normalize(); err != nil {
+					return nil, err
+				}
+				typedReq = &copied
+			}
+		}
+		info.ChainID = requestChainID(typedReq)
+
+		var resp Resp
+		if err := c.doRequest(ctx, info, params(typedReq), &resp); err != nil {
+			return nil, err
+		}
+		info.RiskLevel = responseRiskLevel(&resp)
+		return &resp, nil
 	}
 
-	// Create the request
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	out, err := chainInterceptors(c.interceptors, info, handler)(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("APPID", c.options.AppID)
-	req.Header.Set("APP-SECRET", c.options.AppSecret)
-
-	// Execute the request
-	resp, err := c.options.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+	resp, ok := out.(*Resp)
+	if !ok {
+		return nil, fmt.Errorf("invalid response type for %s: %T", operation, out)
 	}
-	defer resp.Body.Close()
+	return resp, nil
+}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+// request holds the state of a single API request across attempts
+type request struct {
+	info   *CallInfo
+	method string
+	url    string
+	key    string
+	redact *redactor
+}
+
+// doRequest performs an HTTP GET request to the endpoint of the call
+func (c *client) doRequest(ctx context.Context, info *CallInfo, params url.Values, result interface{}) error {
+	// Build the full URL
+	fullURL := c.options.BaseURL + info.Endpoint
+	if len(params) > 0 {
+		fullURL += "?" + params.Encode()
 	}
 
-	if c.options.Debug {
-		log.Printf("[BEOSIN DEBUG] Response: %s\n", string(body))
+	r := &request{
+		info:   info,
+		method: http.MethodGet,
+		url:    fullURL,
+		key:    cacheKey(info.Endpoint, params),
+		redact: c.options.LogOptions.newRedactor(params),
 	}
 
-	// Check HTTP status code
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected http status: %d, body: %s", resp.StatusCode, string(body))
+	// Fetch the response body from the cache or the API
+	body, err := c.fetch(ctx, r)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			info.Code = apiErr.Code
+		}
+		return err
 	}
 
 	// Parse the base response to check for API errors
@@ -105,6 +169,7 @@ func (c *client) doRequest(ctx context.Context, endpoint string, params url.Valu
 	if err := json.Unmarshal(body, &baseResp); err != nil {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
+	info.Code = baseResp.Code
 
 	if !baseResp.IsSuccess() {
 		return NewAPIError(baseResp.Code, baseResp.Msg)
@@ -118,6 +183,193 @@ func (c *client) doRequest(ctx context.Context, endpoint string, params url.Valu
 	return nil
 }
 
+// fetch returns the response body from the cache if possible, otherwise executes the request
+func (c *client) fetch(ctx context.Context, r *request) ([]byte, error) {
+	cache, cacheOptions := c.options.Cache, &c.options.CacheOptions
+	cacheable := cache != nil && cacheOptions.TTL[r.info.Endpoint] > 0
+
+	if cacheable {
+		if body, ok := cache.Get(ctx, r.key); ok {
+			r.info.CacheHit = true
+			c.logAttrs(ctx, slog.LevelDebug, "beosin cache hit",
+				slog.String("operation", r.info.Operation),
+				slog.String("endpoint", r.info.Endpoint),
+			)
+			return body, nil
+		}
+	}
+
+	load := func(ctx context.Context, r *request) ([]byte, error) {
+		body, err := c.execute(ctx, r)
+		if err == nil && cacheable {
+			if ttl := cacheOptions.ttlFor(r.info.Endpoint, responseCode(body)); ttl > 0 {
+				cache.Set(ctx, r.key, body, ttl)
+			}
+		}
+		return body, err
+	}
+
+	if c.flights != nil {
+		return c.flights.do(ctx, r, load)
+	}
+	return load(ctx, r)
+}
+
+// execute sends the request and retries it according to the retry policy
+func (c *client) execute(ctx context.Context, r *request) ([]byte, error) {
+	info := r.info
+	policy := &c.options.Retry
+	credsRefreshed := false
+	for attempt := 1; ; attempt++ {
+		creds, err := c.options.Credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+		}
+
+		// Fail fast while the endpoint group is known to be unavailable
+		recordOutcome, err := c.breakers.allow(info.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.limiters.wait(ctx, info.Endpoint); err != nil {
+			recordOutcome(ctx, errNotSent)
+			return nil, fmt.Errorf("rate limiter wait: %w", err)
+		}
+
+		info.Attempts = attempt
+		info.StatusCode = 0
+		resp, body, err := c.send(ctx, r, creds)
+		c.limiters.observe(info.Endpoint, attemptError(body, err))
+		recordOutcome(ctx, err)
+
+		// Retry once immediately if rotated credentials are available
+		if IsAuthError(err) && !credsRefreshed {
+			credsRefreshed = true
+			if c.refreshCredentials(ctx, creds) {
+				continue
+			}
+		}
+
+		if !policy.shouldRetry(ctx, r.method, attempt, err) {
+			return body, err
+		}
+
+		// Give up early if the caller's deadline would pass before the next attempt
+		wait := policy.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return body, err
+		}
+
+		if c.options.OnRetry != nil {
+			event := RetryEvent{
+				Endpoint: info.Endpoint,
+				Attempt:  attempt,
+				Wait:     wait,
+				Err:      err,
+			}
+			if resp != nil {
+				event.StatusCode = resp.StatusCode
+			}
+			c.options.OnRetry(event)
+		}
+
+		c.logAttrs(ctx, slog.LevelInfo, "beosin request retry",
+			slog.String("operation", info.Operation),
+			slog.String("endpoint", info.Endpoint),
+			slog.Int("attempt", attempt),
+			slog.Duration("wait", wait),
+			slog.Int("status", info.StatusCode),
+			slog.String("error", r.redact.error(err)),
+		)
+
+		if sleepErr := sleepContext(ctx, wait); sleepErr != nil {
+			return body, err
+		}
+	}
+}
+
+// refreshCredentials refreshes the credentials provider after an authentication failure
+// and reports whether different credentials are now available
+func (c *client) refreshCredentials(ctx context.Context, used Credentials) bool {
+	refresher, ok := c.options.Credentials.(CredentialsRefresher)
+	if !ok {
+		return false
+	}
+	if err := refresher.Refresh(ctx); err != nil {
+		c.logAttrs(ctx, slog.LevelWarn, "beosin credentials refresh failed", slog.String("error", err.Error()))
+		return false
+	}
+	creds, err := c.options.Credentials.Retrieve(ctx)
+	return err == nil && creds != used
+}
+
+// send performs a single HTTP attempt and returns the response with its body fully read
+func (c *client) send(ctx context.Context, r *request, creds Credentials) (*http.Response, []byte, error) {
+	info := r.info
+	c.logAttrs(ctx, slog.LevelDebug, "beosin request",
+		slog.String("operation", info.Operation),
+		slog.String("method", r.method),
+		slog.String("url", r.redact.Replace(r.url)),
+		slog.String("app_id", creds.AppID),
+		slog.String("app_secret", redactedValue),
+		slog.Int("attempt", info.Attempts),
+	)
+
+	// Create the request
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	for key, values := range info.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("APPID", creds.AppID)
+	req.Header.Set("APP-SECRET", creds.AppSecret)
+
+	// Execute the request
+	start := time.Now()
+	resp, err := c.options.HTTPClient.Do(req)
+	if err != nil {
+		c.logAttrs(ctx, slog.LevelDebug, "beosin request failed",
+			slog.String("operation", info.Operation),
+			slog.String("endpoint", info.Endpoint),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("error", r.redact.error(err)),
+		)
+		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	info.StatusCode = resp.StatusCode
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if c.logEnabled(ctx, slog.LevelDebug) {
+		c.logAttrs(ctx, slog.LevelDebug, "beosin response",
+			slog.String("operation", info.Operation),
+			slog.String("endpoint", info.Endpoint),
+			slog.Int("status", resp.StatusCode),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("api_code", responseCode(body)),
+			slog.String("body", c.options.LogOptions.truncateBody([]byte(r.redact.body(body)))),
+		)
+	}
+
+	// Check HTTP status code
+	if resp.StatusCode != http.StatusOK {
+		return resp, body, newHTTPError(info.Endpoint, resp, body)
+	}
+
+	return resp, body, nil
+}
+
 // buildQueryParams builds URL query parameters from a map
 func buildQueryParams(params map[string]string) url.Values {
 	values := url.Values{}
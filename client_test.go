@@ -2,7 +2,15 @@ package beosin
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -357,3 +365,180 @@ func TestBlackScreeningDataHasAnyRisk(t *testing.T) {
 		})
 	}
 }
+
+// TestRetryTransientStatus tests that transient HTTP failures are retried
+func TestRetryTransientStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"code":200,"msg":"success","data":{"surplusIntegral":42}}`)
+	}))
+	defer server.Close()
+
+	var retries []RetryEvent
+	client := NewClient("id", "secret",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}),
+		WithRetryHook(func(e RetryEvent) { retries = append(retries, e) }),
+	)
+
+	resp, err := client.GetAccountBalance(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccountBalance failed: %v", err)
+	}
+	if resp.Data.SurplusIntegral != 42 {
+		t.Errorf("Expected 42 credits, got %d", resp.Data.SurplusIntegral)
+	}
+	if len(retries) != 2 {
+		t.Fatalf("Expected 2 retries, got %d", len(retries))
+	}
+	if retries[0].StatusCode != http.StatusBadGateway || retries[0].Attempt != 1 {
+		t.Errorf("Unexpected retry event: %+v", retries[0])
+	}
+}
+
+// TestRetryNonTransientStatus tests that non-transient HTTP failures are not retried
+func TestRetryNonTransientStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithMaxRetries(3))
+
+	if _, err := client.GetAccountBalance(context.Background()); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected 1 call, got %d", n)
+	}
+}
+
+// TestRetryBackoffLimits tests the Retry-After cap and the classification of transport errors
+func TestRetryBackoffLimits(t *testing.T) {
+	policy := RetryPolicy{MaxRetryAfter: 2 * time.Second}
+	policy.applyDefaults()
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"86400"}}}
+	if wait := policy.backoff(1, resp); wait != 2*time.Second {
+		t.Errorf("Expected Retry-After to be capped at 2s, got %s", wait)
+	}
+
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{&net.OpError{Op: "read", Err: errors.New("use of closed network connection")}, false},
+		{&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}}, false},
+		{&net.OpError{Op: "dial", Err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}}, true},
+		{context.Canceled, false},
+		{io.ErrUnexpectedEOF, true},
+	}
+	for _, tt := range tests {
+		if got := isTransientTransportError(tt.err); got != tt.transient {
+			t.Errorf("isTransientTransportError(%v) = %v, expected %v", tt.err, got, tt.transient)
+		}
+	}
+}
+
+// TestWaitDepositTransactionAssessment tests polling while a task is executing
+func TestWaitDepositTransactionAssessment(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			fmt.Fprint(w, `{"code":41038,"msg":"task executing"}`)
+			return
+		}
+		fmt.Fprint(w, `{"code":200,"msg":"success","data":{"score":10,"riskLevel":"Low"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL))
+	policy := PollPolicy{Intervals: []time.Duration{time.Millisecond}, MaxWait: time.Second}
+
+	resp, polls, err := WaitDepositTransactionAssessment(context.Background(), client, &DepositRequest{ChainID: ChainETH, Hash: "0x1"}, policy)
+	if err != nil {
+		t.Fatalf("WaitDepositTransactionAssessment failed: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+	if resp.Data.RiskLevel != RiskLevelLow {
+		t.Errorf("Expected risk level Low, got %s", resp.Data.RiskLevel)
+	}
+}
+
+// TestWaitMaxWaitDuringRequest tests that MaxWait expiring mid-request returns ErrPollTimeout
+func TestWaitMaxWaitDuringRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		fmt.Fprint(w, `{"code":41038,"msg":"task executing"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL))
+	policy := PollPolicy{Intervals: []time.Duration{time.Millisecond}, MaxWait: 50 * time.Millisecond}
+
+	_, _, err := WaitDepositTransactionAssessment(context.Background(), client, &DepositRequest{ChainID: ChainETH, Hash: "0x1"}, policy)
+	if !errors.Is(err, ErrPollTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected ErrPollTimeout wrapping the deadline, got %v", err)
+	}
+}
+
+// TestHTTPError tests that non-200 responses produce a typed HTTPError
+func TestHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"code":40001,"msg":"too many requests"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL))
+
+	_, err := client.GetAccountBalance(context.Background())
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected HTTPError, got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusTooManyRequests || httpErr.Endpoint != EndpointAccountBalance {
+		t.Errorf("Unexpected HTTPError: %+v", httpErr)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsParameterError() {
+		t.Errorf("Expected wrapped parameter APIError, got %v", err)
+	}
+
+	if !IsRateLimited(err) || !IsRetryable(err) || IsAuthError(err) {
+		t.Errorf("Unexpected classification for %v", err)
+	}
+}
+
+// TestAPIErrorSentinels tests errors.Is support for API error codes
+func TestAPIErrorSentinels(t *testing.T) {
+	err := fmt.Errorf("screening failed: %w", NewAPIError(ErrCodeTxHashNotExist, "tx not found"))
+
+	if !errors.Is(err, ErrTxHashNotExist) {
+		t.Error("Expected errors.Is to match ErrTxHashNotExist")
+	}
+	if errors.Is(err, ErrAddressInvalid) {
+		t.Error("Expected errors.Is not to match ErrAddressInvalid")
+	}
+
+	if got := NewAPIError(ErrCodeTaskExecuting, "").Category(); got != ErrorCategoryPending {
+		t.Errorf("Expected pending category, got %s", got)
+	}
+	if got := NewAPIError(99999, "").Category(); got != ErrorCategoryUnknown {
+		t.Errorf("Expected unknown category, got %s", got)
+	}
+}
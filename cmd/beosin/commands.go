@@ -0,0 +1,428 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// errNoData is returned when a successful response carries no data
+var errNoData = errors.New("response has no data")
+
+// query holds what a command needs to call the API for each input
+type query struct {
+	client beosin.Client
+	chain  beosin.Chain
+	inputs []string
+}
+
+// prepareQuery parses the arguments of a command taking addresses or hashes, resolves the
+// chain for the endpoint selected by the flags and creates the client. A non-negative
+// exit code means the command must stop.
+func prepareQuery(env *environment, fs *flag.FlagSet, flags *commonFlags, chainAlias *string, endpoint func() string, args []string) (*query, int) {
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return nil, parseExit(err)
+	}
+	if err := flags.check(); err != nil {
+		return nil, usageError(env, fs, err)
+	}
+	chain, err := resolveChain(*chainAlias, endpoint())
+	if err != nil {
+		return nil, usageError(env, fs, err)
+	}
+	inputs, err := readInputs(env.stdin, positional)
+	if err != nil {
+		return nil, usageError(env, fs, err)
+	}
+	client, err := flags.client()
+	if err != nil {
+		return nil, fail(env, err)
+	}
+	return &query{client: client, chain: chain, inputs: inputs}, -1
+}
+
+// signalContext returns a context that is canceled on interrupt
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// fail reports an error that prevents the command from running
+func fail(env *environment, err error) int {
+	fmt.Fprintf(env.stderr, "beosin: %v\n", err)
+	return exitError
+}
+
+// finish writes the report and returns the exit code
+func finish(env *environment, rep *report, flags commonFlags) int {
+	if err := rep.write(env.stdout, flags.output); err != nil {
+		return fail(env, err)
+	}
+	return rep.exitCode(flags.threshold())
+}
+
+// runBalance shows the remaining credits and equity period
+func runBalance(env *environment, args []string) int {
+	fs := newFlagSet(env)
+	var flags commonFlags
+	flags.register(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return parseExit(err)
+	}
+	if len(positional) > 0 {
+		return usageError(env, fs, fmt.Errorf("unexpected argument %q", positional[0]))
+	}
+	if err := flags.check(); err != nil {
+		return usageError(env, fs, err)
+	}
+	client, err := flags.client()
+	if err != nil {
+		return fail(env, err)
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	rep := newReport("CREDITS", "EQUITY START", "EQUITY END")
+	resp, err := client.GetAccountBalance(ctx)
+	if err == nil && resp.Data == nil {
+		err = errNoData
+	}
+	if err != nil {
+		rep.fail("", err)
+	} else {
+		d := resp.Data
+		rep.add("", d, "", fmt.Sprint(d.SurplusIntegral), formatDate(d.EquityStartDate), formatDate(d.EquityEndDate))
+	}
+	return finish(env, rep, flags)
+}
+
+// runScreen screens addresses against the black address database. Flagged addresses
+// are reported as Severe.
+func runScreen(env *environment, args []string) int {
+	fs := newFlagSet(env)
+	var flags commonFlags
+	flags.register(fs)
+	chain := fs.String("chain", "", "chain ID, name or alias (must support screening)")
+	q, code := prepareQuery(env, fs, &flags, chain, fixed(beosin.EndpointBlackScreening), args)
+	if code >= 0 {
+		return code
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	rep := newReport("ADDRESS", "RISKY", "FLAGS")
+	for _, address := range q.inputs {
+		resp, err := q.client.BlackAddressScreening(ctx, &beosin.BlackScreeningRequest{Platform: q.chain.Platform, Address: address})
+		if err == nil && resp.Data == nil {
+			err = errNoData
+		}
+		if err != nil {
+			rep.fail(address, err)
+			continue
+		}
+
+		d := resp.Data
+		var level beosin.RiskLevel
+		if d.HasAnyRisk() {
+			level = beosin.RiskLevelSevere
+		}
+		rep.add(address, d, level, address, yesNo(d.HasAnyRisk()), strings.Join(screeningFlags(d), ","))
+	}
+	return finish(env, rep, flags)
+}
+
+// screeningFlags returns the JSON names of the risk flags that are set
+func screeningFlags(d *beosin.BlackScreeningData) []string {
+	var names []string
+	v := reflect.ValueOf(d).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Type.Kind() == reflect.Bool && v.Field(i).Bool() {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// runMalicious checks whether addresses are malicious or sanctioned. Such addresses
+// are reported as Severe.
+func runMalicious(env *environment, args []string) int {
+	fs := newFlagSet(env)
+	var flags commonFlags
+	flags.register(fs)
+	chain := fs.String("chain", "", "chain ID, name or alias")
+	q, code := prepareQuery(env, fs, &flags, chain, fixed(beosin.EndpointMaliciousAddress), args)
+	if code >= 0 {
+		return code
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	rep := newReport("ADDRESS", "MALICIOUS", "SANCTIONED", "BLACKLISTED", "TAGS")
+	for _, address := range q.inputs {
+		resp, err := q.client.MaliciousAddressQuery(ctx, &beosin.MaliciousAddressRequest{ChainID: q.chain.ID, Address: address})
+		if err == nil && resp.Data == nil {
+			err = errNoData
+		}
+		if err != nil {
+			rep.fail(address, err)
+			continue
+		}
+
+		d := resp.Data
+		var level beosin.RiskLevel
+		if d.IsMalicious || d.IsSanction || d.IsInCustomerBlackList {
+			level = beosin.RiskLevelSevere
+		}
+		var tags []string
+		if d.MaliceDetail != nil {
+			for _, tag := range d.MaliceDetail.MaliceTags {
+				tags = append(tags, tag.Tag)
+			}
+		}
+		if d.SanctionDetail != nil && d.SanctionDetail.Standard != "" {
+			tags = append(tags, d.SanctionDetail.Standard)
+		}
+		rep.add(address, d, level, address, yesNo(d.IsMalicious), yesNo(d.IsSanction), yesNo(d.IsInCustomerBlackList), strings.Join(tags, ","))
+	}
+	return finish(env, rep, flags)
+}
+
+// runVASP checks whether addresses belong to a VASP. VASP membership is informational
+// and does not affect the exit code.
+func runVASP(env *environment, args []string) int {
+	fs := newFlagSet(env)
+	var flags commonFlags
+	flags.register(fs)
+	chain := fs.String("chain", "", "chain ID, name or alias")
+	q, code := prepareQuery(env, fs, &flags, chain, fixed(beosin.EndpointVASP), args)
+	if code >= 0 {
+		return code
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	rep := newReport("ADDRESS", "VASP", "TAGS")
+	for _, address := range q.inputs {
+		resp, err := q.client.VASPQuery(ctx, &beosin.VASPRequest{ChainID: q.chain.ID, Address: address})
+		if err == nil && resp.Data == nil {
+			err = errNoData
+		}
+		if err != nil {
+			rep.fail(address, err)
+			continue
+		}
+
+		d := resp.Data
+		rep.add(address, d, "", address, yesNo(d.IsVasp), strings.Join(d.VaspTags, ","))
+	}
+	return finish(env, rep, flags)
+}
+
+// runAddressRisk assesses the risk of addresses with the V3 or V4 API
+func runAddressRisk(env *environment, args []string) int {
+	fs := newFlagSet(env)
+	var flags commonFlags
+	flags.register(fs)
+	chain := fs.String("chain", "", "chain ID, name or alias")
+	token := fs.String("token", "", "token address or symbol (defaults to the native token)")
+	v4 := fs.Bool("v4", false, "use the V4 address assessment")
+	endpoint := func() string {
+		if *v4 {
+			return beosin.EndpointV4AddressRisk
+		}
+		return beosin.EndpointAddressRisk
+	}
+	q, code := prepareQuery(env, fs, &flags, chain, endpoint, args)
+	if code >= 0 {
+		return code
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	rep := newReport("ADDRESS", "LEVEL", "SCORE", "INCOMING", "OUTGOING", "TAGS")
+	for _, address := range q.inputs {
+		req := &beosin.AddressRiskRequest{ChainID: q.chain.ID, Address: address, Token: *token}
+		if *v4 {
+			resp, err := q.client.V4EOAAddressRiskAssessment(ctx, req)
+			if err == nil && resp.Data == nil {
+				err = errNoData
+			}
+			if err != nil {
+				rep.fail(address, err)
+				continue
+			}
+			d := resp.Data
+			rep.add(address, d, d.RiskLevel, address, string(d.RiskLevel), formatScore(d.Score),
+				formatLevel(d.IncomingLevel, d.IncomingScore), formatLevel(d.OutgoingLevel, d.OutgoingScore), strings.Join(d.RiskTagDetails, ","))
+			continue
+		}
+
+		resp, err := q.client.EOAAddressRiskAssessment(ctx, req)
+		if err == nil && resp.Data == nil {
+			err = errNoData
+		}
+		if err != nil {
+			rep.fail(address, err)
+			continue
+		}
+		d := resp.Data
+		rep.add(address, d, d.RiskLevel, address, string(d.RiskLevel), formatScore(d.Score),
+			formatLevel(d.IncomingLevel, d.IncomingScore), formatLevel(d.OutgoingLevel, d.OutgoingScore), strings.Join(d.RiskTagDetails, ","))
+	}
+	return finish(env, rep, flags)
+}
+
+// runDeposit assesses deposit transactions
+func runDeposit(env *environment, args []string) int {
+	return runTransaction(env, "deposit", args)
+}
+
+// runWithdraw assesses withdrawal transactions
+func runWithdraw(env *environment, args []string) int {
+	return runTransaction(env, "withdraw", args)
+}
+
+// runTransaction assesses deposit or withdrawal transactions with the V2 or V4 API,
+// optionally waiting for assessments that are still executing
+func runTransaction(env *environment, name string, args []string) int {
+	fs := newFlagSet(env)
+	var flags commonFlags
+	flags.register(fs)
+	chain := fs.String("chain", "", "chain ID, name or alias")
+	token := fs.String("token", "", "token address or symbol (defaults to the native token)")
+	v4 := fs.Bool("v4", false, "use the V4 transaction assessment")
+	wait := fs.Bool("wait", false, "poll assessments that are still executing until they finish")
+
+	deposit := name == "deposit"
+	endpoint := func() string {
+		switch {
+		case deposit && *v4:
+			return beosin.EndpointV4Deposit
+		case deposit:
+			return beosin.EndpointDeposit
+		case *v4:
+			return beosin.EndpointV4Withdraw
+		}
+		return beosin.EndpointWithdraw
+	}
+	q, code := prepareQuery(env, fs, &flags, chain, endpoint, args)
+	if code >= 0 {
+		return code
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	policy := beosin.DefaultPollPolicy()
+	assess := func(hash string) (*beosin.TransactionRiskResponse, error) {
+		switch {
+		case deposit && *wait:
+			resp, _, err := beosin.WaitDepositTransactionAssessment(ctx, q.client, &beosin.DepositRequest{ChainID: q.chain.ID, Hash: hash, Token: *token}, policy)
+			return resp, err
+		case deposit:
+			return q.client.DepositTransactionAssessment(ctx, &beosin.DepositRequest{ChainID: q.chain.ID, Hash: hash, Token: *token})
+		case *wait:
+			resp, _, err := beosin.WaitWithdrawalTransactionAssessment(ctx, q.client, &beosin.WithdrawalRequest{ChainID: q.chain.ID, Hash: hash, Token: *token}, policy)
+			return resp, err
+		}
+		return q.client.WithdrawalTransactionAssessment(ctx, &beosin.WithdrawalRequest{ChainID: q.chain.ID, Hash: hash, Token: *token})
+	}
+	assessV4 := func(hash string) (*beosin.V4TransactionRiskResponse, error) {
+		switch {
+		case deposit && *wait:
+			resp, _, err := beosin.WaitV4DepositTransactionAssessment(ctx, q.client, &beosin.DepositRequest{ChainID: q.chain.ID, Hash: hash, Token: *token}, policy)
+			return resp, err
+		case deposit:
+			return q.client.V4DepositTransactionAssessment(ctx, &beosin.DepositRequest{ChainID: q.chain.ID, Hash: hash, Token: *token})
+		case *wait:
+			resp, _, err := beosin.WaitV4WithdrawalTransactionAssessment(ctx, q.client, &beosin.WithdrawalRequest{ChainID: q.chain.ID, Hash: hash, Token: *token}, policy)
+			return resp, err
+		}
+		return q.client.V4WithdrawalTransactionAssessment(ctx, &beosin.WithdrawalRequest{ChainID: q.chain.ID, Hash: hash, Token: *token})
+	}
+
+	rep := newReport("HASH", "LEVEL", "SCORE", "RISKS")
+	for _, hash := range q.inputs {
+		if *v4 {
+			resp, err := assessV4(hash)
+			if err == nil && resp.Data == nil {
+				err = errNoData
+			}
+			if err != nil {
+				rep.fail(hash, err)
+				continue
+			}
+			d := resp.Data
+			var risks []string
+			for _, risk := range d.Risks {
+				risks = append(risks, fmt.Sprintf("%s (%s)", risk.RiskStrategy, risk.RiskLevel))
+			}
+			rep.add(hash, d, d.RiskLevel, hash, string(d.RiskLevel), formatScore(d.Score), strings.Join(risks, ","))
+			continue
+		}
+
+		resp, err := assess(hash)
+		if err == nil && resp.Data == nil {
+			err = errNoData
+		}
+		if err != nil {
+			rep.fail(hash, err)
+			continue
+		}
+		d := resp.Data
+		var risks []string
+		for _, risk := range d.Risks {
+			risks = append(risks, risk.RiskStrategy)
+		}
+		rep.add(hash, d, d.RiskLevel, hash, string(d.RiskLevel), formatScore(d.Score), strings.Join(risks, ","))
+	}
+	return finish(env, rep, flags)
+}
+
+// runChains lists the supported chains and their aliases
+func runChains(env *environment, args []string) int {
+	fs := newFlagSet(env)
+	var output string
+	fs.StringVar(&output, "output", formatTable, "output format: table, json or csv")
+	fs.StringVar(&output, "o", formatTable, "shorthand for --output")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return parseExit(err)
+	}
+	if len(positional) > 0 {
+		return usageError(env, fs, fmt.Errorf("unexpected argument %q", positional[0]))
+	}
+	if err := checkFormat(output); err != nil {
+		return usageError(env, fs, err)
+	}
+
+	rep := newReport("ID", "NAME", "ALIASES", "FAMILY", "TOKEN", "TIER", "PLATFORM")
+	for _, chain := range beosin.Chains() {
+		rep.add(chain.ID, chain, "", chain.ID, chain.Name, strings.Join(chain.Aliases, ","),
+			string(chain.Family), chain.NativeToken, chain.Tier.String(), chain.Platform)
+	}
+	if err := rep.write(env.stdout, output); err != nil {
+		return fail(env, err)
+	}
+	return exitOK
+}
+
+// fixed returns an endpoint selector for commands calling a single endpoint
+func fixed(endpoint string) func() string {
+	return func() string { return endpoint }
+}
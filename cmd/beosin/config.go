@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// defaultTimeout is the default timeout of each API request
+const defaultTimeout = 30 * time.Second
+
+// errNoInputs is returned when a command is given no addresses or hashes
+var errNoInputs = errors.New("no addresses or hashes given")
+
+// commonFlags are the flags shared by the API commands
+type commonFlags struct {
+	config   string
+	baseURL  string
+	timeout  time.Duration
+	output   string
+	validate bool
+	failOn   string
+}
+
+// register adds the common flags to a flag set
+func (f *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "credentials file of the form {\"appId\": ..., \"appSecret\": ...}")
+	fs.StringVar(&f.baseURL, "base-url", "", "API base URL (defaults to the production API)")
+	fs.DurationVar(&f.timeout, "timeout", defaultTimeout, "timeout of each request")
+	fs.StringVar(&f.output, "output", formatTable, "output format: table, json or csv")
+	fs.StringVar(&f.output, "o", formatTable, "shorthand for --output")
+	fs.BoolVar(&f.validate, "validate", true, "validate and normalize addresses and hashes before sending them")
+	fs.StringVar(&f.failOn, "fail-on", string(beosin.RiskLevelMedium), "lowest risk level that produces a non-zero exit code")
+}
+
+// check validates the flag values
+func (f *commonFlags) check() error {
+	if err := checkFormat(f.output); err != nil {
+		return err
+	}
+	if !beosin.ParseRiskLevel(f.failOn).IsKnown() {
+		return fmt.Errorf("unknown --fail-on level %q (use low, medium, high or severe)", f.failOn)
+	}
+	return nil
+}
+
+// threshold returns the lowest risk level that produces a non-zero exit code
+func (f *commonFlags) threshold() beosin.RiskLevel {
+	return beosin.ParseRiskLevel(f.failOn)
+}
+
+// client creates an API client from the flags
+func (f *commonFlags) client() (beosin.Client, error) {
+	provider, err := credentialsProvider(f.config)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []beosin.Option{
+		beosin.WithCredentialsProvider(provider),
+		beosin.WithTimeout(f.timeout),
+		beosin.WithRequestValidation(f.validate),
+	}
+	if f.baseURL != "" {
+		opts = append(opts, beosin.WithBaseURL(f.baseURL))
+	}
+	return beosin.NewClient("", "", opts...), nil
+}
+
+// credentialsProvider returns the credentials from the given file, the environment or the
+// default credentials file, in that order
+func credentialsProvider(path string) (beosin.CredentialsProvider, error) {
+	if path != "" {
+		return beosin.FileCredentials(path, 0)
+	}
+	if os.Getenv(beosin.EnvAppID) != "" || os.Getenv(beosin.EnvAppSecret) != "" {
+		return beosin.EnvCredentials("", ""), nil
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		path := filepath.Join(dir, "beosin", "credentials.json")
+		if _, err := os.Stat(path); err == nil {
+			return beosin.FileCredentials(path, 0)
+		}
+	}
+	return nil, fmt.Errorf("%w: set %s and %s or pass --config", beosin.ErrMissingCredentials, beosin.EnvAppID, beosin.EnvAppSecret)
+}
+
+// resolveChain looks up a chain by ID, name or alias and checks that it supports the endpoint
+func resolveChain(alias, endpoint string) (beosin.Chain, error) {
+	if alias == "" {
+		return beosin.Chain{}, errors.New("--chain is required")
+	}
+	chain, ok := beosin.LookupChain(alias)
+	if !ok {
+		return beosin.Chain{}, fmt.Errorf("unknown chain %q (run 'beosin chains' for the list)", alias)
+	}
+	if !chain.Supports(endpoint) {
+		return beosin.Chain{}, fmt.Errorf("%s is not supported by this command", chain.Name)
+	}
+	return chain, nil
+}
+
+// readInputs returns the positional arguments, replacing "-" with the lines of stdin.
+// Blank lines and lines starting with # are skipped.
+func readInputs(stdin io.Reader, args []string) ([]string, error) {
+	var inputs []string
+	for _, arg := range args {
+		if arg != "-" {
+			inputs = append(inputs, arg)
+			continue
+		}
+
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				inputs = append(inputs, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read stdin: %w", err)
+		}
+	}
+	if len(inputs) == 0 {
+		return nil, errNoInputs
+	}
+	return inputs, nil
+}
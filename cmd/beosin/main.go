@@ -0,0 +1,192 @@
+// Command beosin queries the Beosin KYT/KYA API from the command line.
+//
+// Usage:
+//
+//	beosin <command> [flags] [address|hash ...]
+//
+// Commands:
+//
+//	balance        show the remaining credits and equity period
+//	screen         screen addresses against the black address database
+//	malicious      check whether addresses are malicious or sanctioned
+//	vasp           check whether addresses belong to a VASP
+//	address-risk   assess the risk of addresses (--v4 for the V4 API)
+//	deposit        assess deposit transactions (--v4 for the V4 API)
+//	withdraw       assess withdrawal transactions (--v4 for the V4 API)
+//	chains         list the supported chains and their aliases
+//
+// Credentials are read from the file given by --config, from the BEOSIN_APP_ID and
+// BEOSIN_APP_SECRET environment variables, or from beosin/credentials.json in the user
+// configuration directory, in that order. An argument of "-" reads one address or hash
+// per line from standard input.
+//
+// Exit codes:
+//
+//	0  every result is below the --fail-on level
+//	1  a request failed, whatever risk the other results carry
+//	2  invalid usage
+//	3  highest risk level is Low
+//	4  highest risk level is Medium
+//	5  highest risk level is High
+//	6  highest risk level is Severe (also used for flagged, malicious or sanctioned addresses)
+//
+// Risk exit codes are only returned when the highest level is at or above --fail-on
+// (Medium by default) and every query succeeded: a failed query exits with 1 even among
+// risky results, so a partial run is never mistaken for a complete one. Unknown risk
+// levels count as Severe.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+
+	// exitRiskBase is added to the rank of the highest risk level (Low is 1)
+	exitRiskBase = 2
+)
+
+// command is a subcommand of the tool
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(env *environment, args []string) int
+}
+
+// commands lists the subcommands in help order
+var commands = []command{
+	{name: "balance", usage: "balance [flags]", summary: "show the remaining credits and equity period", run: runBalance},
+	{name: "screen", usage: "screen --chain <chain> [flags] <address>...", summary: "screen addresses against the black address database", run: runScreen},
+	{name: "malicious", usage: "malicious --chain <chain> [flags] <address>...", summary: "check whether addresses are malicious or sanctioned", run: runMalicious},
+	{name: "vasp", usage: "vasp --chain <chain> [flags] <address>...", summary: "check whether addresses belong to a VASP", run: runVASP},
+	{name: "address-risk", usage: "address-risk --chain <chain> [--v4] [flags] <address>...", summary: "assess the risk of addresses", run: runAddressRisk},
+	{name: "deposit", usage: "deposit --chain <chain> [--v4] [--wait] [flags] <hash>...", summary: "assess deposit transactions", run: runDeposit},
+	{name: "withdraw", usage: "withdraw --chain <chain> [--v4] [--wait] [flags] <hash>...", summary: "assess withdrawal transactions", run: runWithdraw},
+	{name: "chains", usage: "chains [flags]", summary: "list the supported chains and their aliases", run: runChains},
+}
+
+// environment holds the process streams so commands can be tested in-process
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// command is the subcommand being run
+	command command
+}
+
+func main() {
+	os.Exit(run(&environment{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}, os.Args[1:]))
+}
+
+// run executes the command line and returns the exit code
+func run(env *environment, args []string) int {
+	if len(args) == 0 {
+		printUsage(env.stderr)
+		return exitUsage
+	}
+
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		printUsage(env.stdout)
+		return exitOK
+	}
+	for _, cmd := range commands {
+		if cmd.name == name {
+			env.command = cmd
+			return cmd.run(env, args[1:])
+		}
+	}
+
+	fmt.Fprintf(env.stderr, "beosin: unknown command %q\n\n", name)
+	printUsage(env.stderr)
+	return exitUsage
+}
+
+// printUsage writes the list of commands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: beosin <command> [flags] [address|hash ...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes:")
+	fmt.Fprintln(w, "  0    every result is below the --fail-on level")
+	fmt.Fprintln(w, "  1    a request failed (takes precedence over risk codes)")
+	fmt.Fprintln(w, "  2    invalid usage")
+	fmt.Fprintln(w, "  3-6  highest risk level at or above --fail-on: Low, Medium, High or Severe")
+	fmt.Fprintln(w, "       (unknown levels count as Severe)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'beosin <command> -h' for the flags of a command.")
+}
+
+// newFlagSet creates the flag set of the subcommand being run
+func newFlagSet(env *environment) *flag.FlagSet {
+	cmd := env.command
+	fs := flag.NewFlagSet("beosin "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: beosin %s\n\n%s.\n\nFlags:\n", cmd.usage, upperFirst(cmd.summary))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses flags that may appear before, between or after the positional
+// arguments. Everything after a "--" terminator is positional.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	if i := slices.Index(args, "--"); i >= 0 {
+		args, rest = args[:i], args[i+1:]
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseExit returns the exit code for a flag parsing error, which the flag
+// package has already reported
+func parseExit(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	return exitUsage
+}
+
+// usageError reports a usage problem and returns the usage exit code
+func usageError(env *environment, fs *flag.FlagSet, err error) int {
+	fmt.Fprintf(env.stderr, "%s: %v\nRun '%s -h' for usage.\n", fs.Name(), err, fs.Name())
+	return exitUsage
+}
+
+// upperFirst capitalizes the first letter of s
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+	"github.com/ABT-Tech-Limited/beosin-go/beosintest"
+)
+
+const (
+	testAddress      = "0x52908400098527886E0F7030069857D2E4169EE7"
+	testRiskyAddress = "0x8617E340B3D01FA5F11F306F4090FD50E238070D"
+	testHash         = "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+)
+
+// runTest runs the command line against the server and returns the exit code and output
+func runTest(t *testing.T, server *beosintest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	t.Setenv(beosin.EnvAppID, beosintest.DefaultAppID)
+	t.Setenv(beosin.EnvAppSecret, beosintest.DefaultAppSecret)
+
+	var stdout, stderr bytes.Buffer
+	env := &environment{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr}
+	if server != nil && len(args) > 0 {
+		args = append(args, "--base-url", server.URL)
+	}
+	code := run(env, args)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestAddressRisk tests chain alias resolution, JSON output and risk exit codes
+func TestAddressRisk(t *testing.T) {
+	server := beosintest.NewServer()
+	defer server.Close()
+	server.On(beosin.EndpointV4AddressRisk).ForAddress(testRiskyAddress).
+		Reply(&beosin.V4AddressRiskData{Score: 80, RiskLevel: beosin.RiskLevelHigh})
+
+	code, stdout, stderr := runTest(t, server, "", "address-risk", "--chain", "Ethereum", "--v4", "-o", "json", testAddress, testRiskyAddress)
+	if code != exitRiskBase+beosin.RiskLevelHigh.Rank() {
+		t.Fatalf("Expected the High exit code, got %d (stderr: %s)", code, stderr)
+	}
+
+	var results []struct {
+		Input string                   `json:"input"`
+		Data  beosin.V4AddressRiskData `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(results) != 2 || results[1].Input != testRiskyAddress || results[1].Data.RiskLevel != beosin.RiskLevelHigh {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	calls := server.CallsTo(beosin.EndpointV4AddressRisk)
+	if len(calls) != 2 || calls[0].Query.Get("chainId") != beosin.ChainETH {
+		t.Errorf("Expected two calls on chain %s, got %+v", beosin.ChainETH, calls)
+	}
+
+	// Low risk stays below the default threshold unless it is lowered
+	if code, _, _ := runTest(t, server, "", "address-risk", "--chain", "eth", testAddress); code != exitOK {
+		t.Errorf("Expected exit code %d for a low risk address, got %d", exitOK, code)
+	}
+	if code, _, _ := runTest(t, server, "", "address-risk", "--chain", "eth", "--fail-on", "low", testAddress); code != exitRiskBase+1 {
+		t.Errorf("Expected exit code %d with --fail-on low, got %d", exitRiskBase+1, code)
+	}
+}
+
+// TestScreen tests the screening platform, stdin input and CSV output
+func TestScreen(t *testing.T) {
+	server := beosintest.NewServer()
+	defer server.Close()
+	server.On(beosin.EndpointBlackScreening).ForAddress(testRiskyAddress).
+		Reply(&beosin.BlackScreeningData{Sanction: true, Mixing: true})
+
+	stdin := "# addresses\n" + testAddress + "\n\n" + testRiskyAddress + "\n"
+	code, stdout, stderr := runTest(t, server, stdin, "screen", "--chain", "bnb", "--output", "csv", "-")
+	if code != exitRiskBase+beosin.RiskLevelSevere.Rank() {
+		t.Fatalf("Expected the Severe exit code, got %d (stderr: %s)", code, stderr)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV output: %v", err)
+	}
+	want := [][]string{
+		{"ADDRESS", "RISKY", "FLAGS", "ERROR"},
+		{testAddress, "no", "", ""},
+		{testRiskyAddress, "yes", "sanction,mixing", ""},
+	}
+	if !slices.EqualFunc(rows, want, slices.Equal) {
+		t.Errorf("Unexpected CSV rows: %q", rows)
+	}
+
+	for _, call := range server.CallsTo(beosin.EndpointBlackScreening) {
+		if call.Query.Get("platform") != "bsc" {
+			t.Errorf("Expected platform bsc, got %q", call.Query.Get("platform"))
+		}
+	}
+}
+
+// TestTransactionAndErrors tests transaction commands, table output and error exit codes
+func TestTransactionAndErrors(t *testing.T) {
+	server := beosintest.NewServer()
+	defer server.Close()
+	server.On(beosin.EndpointWithdraw).ForHash(testHash).Reply(&beosin.TransactionRiskData{
+		Score:     50,
+		RiskLevel: beosin.RiskLevelMedium,
+		Risks:     []beosin.Risk{{RiskStrategy: "Mixer"}},
+	})
+	server.On(beosin.EndpointMaliciousAddress).ReplyError(beosin.ErrCodeAddressError, "bad address")
+
+	code, stdout, _ := runTest(t, server, "", "withdraw", testHash, "--chain", "eth")
+	if code != exitRiskBase+beosin.RiskLevelMedium.Rank() {
+		t.Errorf("Expected the Medium exit code, got %d", code)
+	}
+	if !strings.HasPrefix(stdout, "HASH") || !strings.Contains(stdout, "Mixer") {
+		t.Errorf("Unexpected table output:\n%s", stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "", "malicious", "--chain", "eth", testAddress)
+	if code != exitError || !strings.Contains(stdout, "bad address") {
+		t.Errorf("Expected exit code %d with the error in the output, got %d:\n%s", exitError, code, stdout)
+	}
+
+	code, _, stderr := runTest(t, server, "", "deposit", "--chain", "base", testHash)
+	if code != exitUsage || !strings.Contains(stderr, "not supported") {
+		t.Errorf("Expected a usage error for a basic-tier chain, got %d: %s", code, stderr)
+	}
+
+	usage := [][]string{
+		{"vasp", "--chain", "nochain", testAddress},
+		{"vasp", "--chain", "eth"},
+		{"vasp", testAddress},
+		{"screen", "--chain", "ltc", testAddress},
+		{"balance", "--output", "xml"},
+		{"address-risk", "--chain", "eth", "--fail-on", "extreme", testAddress},
+		{"unknown"},
+		{},
+	}
+	for _, args := range usage {
+		if code, _, _ := runTest(t, server, "", args...); code != exitUsage {
+			t.Errorf("Expected exit code %d for %q, got %d", exitUsage, args, code)
+		}
+	}
+	if len(server.CallsTo(beosin.EndpointVASP)) != 0 {
+		t.Error("Expected no requests for invalid command lines")
+	}
+}
+
+// TestExitCodePrecedence tests that failed queries take precedence over a risk at or above
+// the threshold and that unknown risk levels count as Severe
+func TestExitCodePrecedence(t *testing.T) {
+	server := beosintest.NewServer()
+	defer server.Close()
+	server.On(beosin.EndpointV4AddressRisk).ForAddress(testRiskyAddress).
+		Reply(&beosin.V4AddressRiskData{Score: 80, RiskLevel: beosin.RiskLevelHigh})
+	server.On(beosin.EndpointV4AddressRisk).ForAddress(testAddress).ReplyError(beosin.ErrCodeAddressError, "bad address")
+
+	code, stdout, _ := runTest(t, server, "", "address-risk", "--chain", "eth", "--v4", testAddress, testRiskyAddress)
+	if code != exitError || !strings.Contains(stdout, "bad address") {
+		t.Errorf("Expected exit code %d with the error in the output, got %d:\n%s", exitError, code, stdout)
+	}
+	if code, _, _ := runTest(t, server, "", "address-risk", "--chain", "eth", "--v4", testRiskyAddress); code != exitRiskBase+beosin.RiskLevelHigh.Rank() {
+		t.Errorf("Expected the High exit code when every query succeeded, got %d", code)
+	}
+	if code, _, _ := runTest(t, server, "", "address-risk", "--chain", "eth", "--v4", "--fail-on", "severe", testAddress, testRiskyAddress); code != exitError {
+		t.Errorf("Expected exit code %d below the threshold, got %d", exitError, code)
+	}
+
+	server.On(beosin.EndpointV4AddressRisk).ForAddress(testRiskyAddress).
+		Reply(&beosin.V4AddressRiskData{Score: 99, RiskLevel: "Critical"})
+	if code, _, _ := runTest(t, server, "", "address-risk", "--chain", "eth", "--v4", testRiskyAddress); code != exitRiskBase+beosin.RiskLevelSevere.Rank() {
+		t.Errorf("Expected the Severe exit code for an unknown level, got %d", code)
+	}
+}
+
+// TestBalanceCredentialsFile tests the balance command with a credentials file
+func TestBalanceCredentialsFile(t *testing.T) {
+	server := beosintest.NewServer(beosintest.WithCredentials("file-id", "file-secret"))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte(`{"appId": "file-id", "appSecret": "file-secret"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runTest(t, server, "", "balance", "--config", path)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "CREDITS") || !strings.Contains(stdout, "1000000") {
+		t.Errorf("Unexpected balance output:\n%s", stdout)
+	}
+
+	// The environment credentials do not match the server
+	if code, _, _ := runTest(t, server, "", "balance"); code != exitError {
+		t.Errorf("Expected exit code %d for rejected credentials, got %d", exitError, code)
+	}
+}
+
+// TestParseArgs tests flags between positional arguments and the -- terminator
+func TestParseArgs(t *testing.T) {
+	env := &environment{stderr: &bytes.Buffer{}}
+	fs := newFlagSet(env)
+	chain := fs.String("chain", "", "")
+	v4 := fs.Bool("v4", false, "")
+
+	positional, err := parseArgs(fs, []string{"a", "--chain", "eth", "b", "--v4", "--", "--c"})
+	if err != nil {
+		t.Fatalf("parseArgs failed: %v", err)
+	}
+	if !slices.Equal(positional, []string{"a", "b", "--c"}) || *chain != "eth" || !*v4 {
+		t.Errorf("Unexpected parse result: %q chain=%q v4=%v", positional, *chain, *v4)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	beosin "github.com/ABT-Tech-Limited/beosin-go"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// checkFormat validates an output format
+func checkFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q (use table, json or csv)", format)
+}
+
+// result is the outcome of one query
+type result struct {
+	// Input is the queried address or hash
+	Input string `json:"input,omitempty"`
+
+	// Data is the response data of a successful query
+	Data any `json:"data,omitempty"`
+
+	// Error is the error message of a failed query
+	Error string `json:"error,omitempty"`
+
+	level  beosin.RiskLevel
+	failed bool
+	row    []string
+}
+
+// report collects the results of a command
+type report struct {
+	columns []string
+	results []result
+}
+
+// newReport creates a report with the given table and CSV columns
+func newReport(columns ...string) *report {
+	return &report{columns: columns}
+}
+
+// add records a successful query with its risk level and column values
+func (r *report) add(input string, data any, level beosin.RiskLevel, row ...string) {
+	r.results = append(r.results, result{Input: input, Data: data, level: level, row: row})
+}
+
+// fail records a failed query
+func (r *report) fail(input string, err error) {
+	r.results = append(r.results, result{Input: input, Error: err.Error(), failed: true})
+}
+
+// exitCode returns exitError if any query failed, since the unchecked inputs may carry
+// a higher risk. Otherwise it returns the exit code for the highest risk level at or
+// above the threshold, with unknown levels counting as Severe.
+func (r *report) exitCode(threshold beosin.RiskLevel) int {
+	var levels []beosin.RiskLevel
+	for _, res := range r.results {
+		if res.failed {
+			return exitError
+		}
+		levels = append(levels, res.level)
+	}
+
+	if max := beosin.MaxRiskLevel(levels...); max != "" && max.AtLeast(threshold) {
+		return exitRiskBase + max.Rank()
+	}
+	return exitOK
+}
+
+// write renders the report in the given format. CSV output ends with an ERROR column
+// holding the error message of failed queries; table output only shows it when a
+// query failed.
+func (r *report) write(w io.Writer, format string) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		results := r.results
+		if results == nil {
+			results = []result{}
+		}
+		return enc.Encode(results)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		for _, row := range r.rows() {
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	failed := slices.ContainsFunc(r.results, func(res result) bool { return res.failed })
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range r.rows() {
+		if !failed {
+			row = row[:len(row)-1]
+		}
+		for i, cell := range row {
+			if cell == "" {
+				row[i] = "-"
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// rows returns the header and one row per result, padding failed queries
+func (r *report) rows() [][]string {
+	rows := [][]string{append(append([]string(nil), r.columns...), "ERROR")}
+	for _, res := range r.results {
+		row := make([]string, len(r.columns)+1)
+		if len(r.columns) > 0 && res.Input != "" {
+			row[0] = res.Input
+		}
+		copy(row, res.row)
+		row[len(r.columns)] = res.Error
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// yesNo formats a flag
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// formatScore formats a risk score without trailing zeros
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+// formatLevel formats a risk level with its score
+func formatLevel(level beosin.RiskLevel, score float64) string {
+	if level == "" {
+		return formatScore(score)
+	}
+	return fmt.Sprintf("%s (%s)", level, formatScore(score))
+}
+
+// formatDate formats a Unix timestamp in seconds or milliseconds as a UTC date
+func formatDate(ts int64) string {
+	if ts <= 0 {
+		return ""
+	}
+	if ts > 1e12 {
+		return time.UnixMilli(ts).UTC().Format(time.DateOnly)
+	}
+	return time.Unix(ts, 0).UTC().Format(time.DateOnly)
+}
@@ -1,84 +1,76 @@
 package beosin
 
-import "context"
+import (
+	"context"
+	"net/url"
+)
 
 const (
-	endpointDeposit          = "/api/v2/kyt/tx/deposit"
-	endpointWithdraw         = "/api/v2/kyt/tx/withdraw"
-	endpointAddressRisk      = "/api/v3/kyt/address/risk"
-	endpointMaliciousAddress = "/api/v2/kyt/tag/malicious"
-	endpointVASP             = "/api/v2/kyt/tag/vasp"
+	// EndpointDeposit is the endpoint for deposit transaction assessment
+	EndpointDeposit = "/api/v2/kyt/tx/deposit"
+
+	// EndpointWithdraw is the endpoint for withdrawal transaction assessment
+	EndpointWithdraw = "/api/v2/kyt/tx/withdraw"
+
+	// EndpointAddressRisk is the endpoint for EOA address risk assessment
+	EndpointAddressRisk = "/api/v3/kyt/address/risk"
+
+	// EndpointMaliciousAddress is the endpoint for malicious address queries
+	EndpointMaliciousAddress = "/api/v2/kyt/tag/malicious"
+
+	// EndpointVASP is the endpoint for VASP queries
+	EndpointVASP = "/api/v2/kyt/tag/vasp"
 )
 
 // DepositTransactionAssessment performs risk assessment on deposit transactions
 func (c *client) DepositTransactionAssessment(ctx context.Context, req *DepositRequest) (*TransactionRiskResponse, error) {
-	params := buildQueryParams(map[string]string{
-		"chainId": req.ChainID,
-		"hash":    req.Hash,
-		"token":   req.Token,
+	return invoke[TransactionRiskResponse](ctx, c, OperationDepositTransactionAssessment, EndpointDeposit, req, func(req *DepositRequest) url.Values {
+		return buildQueryParams(map[string]string{
+			"chainId": req.ChainID,
+			"hash":    req.Hash,
+			"token":   req.Token,
+		})
 	})
-
-	var resp TransactionRiskResponse
-	if err := c.doRequest(ctx, endpointDeposit, params, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
 }
 
 // WithdrawalTransactionAssessment performs risk assessment on withdrawal transactions
 func (c *client) WithdrawalTransactionAssessment(ctx context.Context, req *WithdrawalRequest) (*TransactionRiskResponse, error) {
-	params := buildQueryParams(map[string]string{
-		"chainId": req.ChainID,
-		"hash":    req.Hash,
-		"token":   req.Token,
+	return invoke[TransactionRiskResponse](ctx, c, OperationWithdrawalTransactionAssessment, EndpointWithdraw, req, func(req *WithdrawalRequest) url.Values {
+		return buildQueryParams(map[string]string{
+			"chainId": req.ChainID,
+			"hash":    req.Hash,
+			"token":   req.Token,
+		})
 	})
-
-	var resp TransactionRiskResponse
-	if err := c.doRequest(ctx, endpointWithdraw, params, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
 }
 
 // EOAAddressRiskAssessment performs risk assessment on EOA addresses
 func (c *client) EOAAddressRiskAssessment(ctx context.Context, req *AddressRiskRequest) (*AddressRiskResponse, error) {
-	params := buildQueryParams(map[string]string{
-		"chainId": req.ChainID,
-		"address": req.Address,
-		"token":   req.Token,
+	return invoke[AddressRiskResponse](ctx, c, OperationEOAAddressRiskAssessment, EndpointAddressRisk, req, func(req *AddressRiskRequest) url.Values {
+		return buildQueryParams(map[string]string{
+			"chainId": req.ChainID,
+			"address": req.Address,
+			"token":   req.Token,
+		})
 	})
-
-	var resp AddressRiskResponse
-	if err := c.doRequest(ctx, endpointAddressRisk, params, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
 }
 
 // MaliciousAddressQuery queries if an address is malicious
 func (c *client) MaliciousAddressQuery(ctx context.Context, req *MaliciousAddressRequest) (*MaliciousAddressResponse, error) {
-	params := buildQueryParams(map[string]string{
-		"chainId": req.ChainID,
-		"address": req.Address,
+	return invoke[MaliciousAddressResponse](ctx, c, OperationMaliciousAddressQuery, EndpointMaliciousAddress, req, func(req *MaliciousAddressRequest) url.Values {
+		return buildQueryParams(map[string]string{
+			"chainId": req.ChainID,
+			"address": req.Address,
+		})
 	})
-
-	var resp MaliciousAddressResponse
-	if err := c.doRequest(ctx, endpointMaliciousAddress, params, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
 }
 
 // VASPQuery queries if an address is a VASP entity
 func (c *client) VASPQuery(ctx context.Context, req *VASPRequest) (*VASPResponse, error) {
-	params := buildQueryParams(map[string]string{
-		"chainId": req.ChainID,
-		"address": req.Address,
+	return invoke[VASPResponse](ctx, c, OperationVASPQuery, EndpointVASP, req, func(req *VASPRequest) url.Values {
+		return buildQueryParams(map[string]string{
+			"chainId": req.ChainID,
+			"address": req.Address,
+		})
 	})
-
-	var resp VASPResponse
-	if err := c.doRequest(ctx, endpointVASP, params, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
 }
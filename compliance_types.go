@@ -81,7 +81,7 @@ type TransactionRiskData struct {
 	Score float64 `json:"score"`
 
 	// RiskLevel is the risk level (Severe/High/Medium/Low)
-	RiskLevel string `json:"riskLevel"`
+	RiskLevel RiskLevel `json:"riskLevel"`
 
 	// Risks contains the list of detected risks
 	Risks []Risk `json:"risks"`
@@ -108,13 +108,13 @@ type AddressRiskData struct {
 	Score float64 `json:"score"`
 
 	// RiskLevel is the overall risk level
-	RiskLevel string `json:"riskLevel"`
+	RiskLevel RiskLevel `json:"riskLevel"`
 
 	// IncomingScore is the deposit risk score
 	IncomingScore float64 `json:"incomingScore"`
 
 	// IncomingLevel is the deposit risk level
-	IncomingLevel string `json:"incomingLevel"`
+	IncomingLevel RiskLevel `json:"incomingLevel"`
 
 	// IncomingDetail contains deposit risk details
 	IncomingDetail []StrategyRiskDetail `json:"incomingDetail"`
@@ -123,7 +123,7 @@ type AddressRiskData struct {
 	OutgoingScore float64 `json:"outgoingScore"`
 
 	// OutgoingLevel is the withdrawal risk level
-	OutgoingLevel string `json:"outgoingLevel"`
+	OutgoingLevel RiskLevel `json:"outgoingLevel"`
 
 	// OutgoingDetail contains withdrawal risk details
 	OutgoingDetail []StrategyRiskDetail `json:"outgoingDetail"`
@@ -132,7 +132,7 @@ type AddressRiskData struct {
 	RiskTagScore float64 `json:"riskTagScore"`
 
 	// RiskTagLevel is the risk tag level
-	RiskTagLevel string `json:"riskTagLevel"`
+	RiskTagLevel RiskLevel `json:"riskTagLevel"`
 
 	// RiskTagDetails contains risk tag types
 	RiskTagDetails []string `json:"riskTagDetails"`
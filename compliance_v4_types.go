@@ -24,7 +24,7 @@ type V4Risk struct {
 	Exposure string `json:"exposure"`
 
 	// RiskLevel is the risk level for this strategy
-	RiskLevel string `json:"riskLevel"`
+	RiskLevel RiskLevel `json:"riskLevel"`
 
 	// Hops is the shortest hop count to risk entity
 	Hops int `json:"hops"`
@@ -45,7 +45,7 @@ type V4TransactionRiskData struct {
 	Score float64 `json:"score"`
 
 	// RiskLevel is the overall risk level (Severe/High/Medium/Low)
-	RiskLevel string `json:"riskLevel"`
+	RiskLevel RiskLevel `json:"riskLevel"`
 
 	// Risks contains the list of detected risks
 	Risks []V4Risk `json:"risks"`
@@ -66,7 +66,7 @@ type V4StrategyDetail struct {
 	Exposure string `json:"exposure"`
 
 	// RiskLevel is the risk level for this strategy
-	RiskLevel string `json:"riskLevel"`
+	RiskLevel RiskLevel `json:"riskLevel"`
 
 	// Hops is the hop count
 	Hops int `json:"hops"`
@@ -87,13 +87,13 @@ type V4AddressRiskData struct {
 	Score float64 `json:"score"`
 
 	// RiskLevel is the overall risk level
-	RiskLevel string `json:"riskLevel"`
+	RiskLevel RiskLevel `json:"riskLevel"`
 
 	// IncomingScore is the incoming risk score
 	IncomingScore float64 `json:"incomingScore"`
 
 	// IncomingLevel is the incoming risk level
-	IncomingLevel string `json:"incomingLevel"`
+	IncomingLevel RiskLevel `json:"incomingLevel"`
 
 	// IncomingDetail contains incoming risk details
 	IncomingDetail []V4StrategyDetail `json:"incomingDetail"`
@@ -102,7 +102,7 @@ type V4AddressRiskData struct {
 	OutgoingScore float64 `json:"outgoingScore"`
 
 	// OutgoingLevel is the outgoing risk level
-	OutgoingLevel string `json:"outgoingLevel"`
+	OutgoingLevel RiskLevel `json:"outgoingLevel"`
 
 	// OutgoingDetail contains outgoing risk details
 	OutgoingDetail []V4StrategyDetail `json:"outgoingDetail"`
@@ -111,7 +111,7 @@ type V4AddressRiskData struct {
 	RiskTagScore float64 `json:"riskTagScore"`
 
 	// RiskTagLevel is the risk tag level
-	RiskTagLevel string `json:"riskTagLevel"`
+	RiskTagLevel RiskLevel `json:"riskTagLevel"`
 
 	// RiskTagDetails contains risk tag types
 	RiskTagDetails []string `json:"riskTagDetails"`
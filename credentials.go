@@ -0,0 +1,176 @@
+package beosin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvAppID is the default environment variable holding the application ID
+	EnvAppID = "BEOSIN_APP_ID"
+
+	// EnvAppSecret is the default environment variable holding the application secret
+	EnvAppSecret = "BEOSIN_APP_SECRET"
+
+	// DefaultCredentialsCheckInterval is the default interval between credentials file change checks
+	DefaultCredentialsCheckInterval = 10 * time.Second
+)
+
+// ErrMissingCredentials is returned when a provider has no application ID or secret
+var ErrMissingCredentials = errors.New("beosin: missing credentials")
+
+// Credentials holds the application ID and secret used to authenticate requests
+type Credentials struct {
+	// AppID is the application ID
+	AppID string `json:"appId"`
+
+	// AppSecret is the application secret
+	AppSecret string `json:"appSecret"`
+}
+
+// validate checks that both values are present
+func (c Credentials) validate() error {
+	if c.AppID == "" || c.AppSecret == "" {
+		return ErrMissingCredentials
+	}
+	return nil
+}
+
+// CredentialsProvider supplies the credentials for each request.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	// Retrieve returns the credentials to use for a request
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsRefresher is implemented by providers that can reload their credentials on demand.
+// The client calls Refresh once when the API rejects the current credentials.
+type CredentialsRefresher interface {
+	// Refresh reloads the credentials from their source
+	Refresh(ctx context.Context) error
+}
+
+// staticCredentials is a provider for fixed credentials
+type staticCredentials struct {
+	creds Credentials
+}
+
+// StaticCredentials returns a provider that always returns the given credentials
+func StaticCredentials(appID, appSecret string) CredentialsProvider {
+	return &staticCredentials{creds: Credentials{AppID: appID, AppSecret: appSecret}}
+}
+
+// Retrieve implements CredentialsProvider
+func (p *staticCredentials) Retrieve(context.Context) (Credentials, error) {
+	return p.creds, nil
+}
+
+// envCredentials is a provider reading environment variables on every request
+type envCredentials struct {
+	appIDVar     string
+	appSecretVar string
+}
+
+// EnvCredentials returns a provider that reads the credentials from environment variables
+// on every request. Empty variable names default to EnvAppID and EnvAppSecret.
+func EnvCredentials(appIDVar, appSecretVar string) CredentialsProvider {
+	if appIDVar == "" {
+		appIDVar = EnvAppID
+	}
+	if appSecretVar == "" {
+		appSecretVar = EnvAppSecret
+	}
+	return &envCredentials{appIDVar: appIDVar, appSecretVar: appSecretVar}
+}
+
+// Retrieve implements CredentialsProvider
+func (p *envCredentials) Retrieve(context.Context) (Credentials, error) {
+	creds := Credentials{
+		AppID:     os.Getenv(p.appIDVar),
+		AppSecret: os.Getenv(p.appSecretVar),
+	}
+	if err := creds.validate(); err != nil {
+		return Credentials{}, fmt.Errorf("%w: %s and %s must be set", err, p.appIDVar, p.appSecretVar)
+	}
+	return creds, nil
+}
+
+// FileCredentialsProvider reads credentials from a JSON file of the form
+// {"appId": "...", "appSecret": "..."} and reloads it when the file changes.
+type FileCredentialsProvider struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	creds     Credentials
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+// FileCredentials returns a provider that reads credentials from a JSON file and checks it
+// for changes at most once per checkInterval (0 uses DefaultCredentialsCheckInterval)
+func FileCredentials(path string, checkInterval time.Duration) (*FileCredentialsProvider, error) {
+	if checkInterval <= 0 {
+		checkInterval = DefaultCredentialsCheckInterval
+	}
+	p := &FileCredentialsProvider{path: path, checkInterval: checkInterval}
+	if err := p.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Retrieve implements CredentialsProvider
+func (p *FileCredentialsProvider) Retrieve(context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.lastCheck) >= p.checkInterval {
+		p.lastCheck = time.Now()
+		info, err := os.Stat(p.path)
+		if err == nil && (!info.ModTime().Equal(p.modTime) || info.Size() != p.size) {
+			// Keep serving the previous credentials if the new file cannot be loaded
+			_ = p.load()
+		}
+	}
+	return p.creds, nil
+}
+
+// Refresh implements CredentialsRefresher
+func (p *FileCredentialsProvider) Refresh(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastCheck = time.Now()
+	return p.load()
+}
+
+// load reads the credentials file; the caller must hold the lock
+func (p *FileCredentialsProvider) load() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	if err := creds.validate(); err != nil {
+		return fmt.Errorf("%w in %s", err, p.path)
+	}
+
+	p.creds = creds
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return nil
+}
@@ -0,0 +1,71 @@
+package beosin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFileCredentialsRotation tests that an auth failure refreshes rotated credentials and retries once
+func TestFileCredentialsRotation(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("APP-SECRET") != "new-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"surplusIntegral":1}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte(`{"appId":"id","appSecret":"old-secret"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := FileCredentials(path, time.Hour)
+	if err != nil {
+		t.Fatalf("FileCredentials failed: %v", err)
+	}
+
+	client := NewClient("", "", WithBaseURL(server.URL), WithCredentialsProvider(provider))
+	ctx := context.Background()
+
+	if _, err := client.GetAccountBalance(ctx); !IsAuthError(err) {
+		t.Fatalf("Expected auth error with old credentials, got %v", err)
+	}
+
+	// Rotate the secret; the long check interval means only the refresh picks it up
+	if err := os.WriteFile(path, []byte(`{"appId":"id","appSecret":"new-secret"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAccountBalance(ctx); err != nil {
+		t.Fatalf("Expected rotated credentials to be used, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("Expected 3 HTTP calls, got %d", n)
+	}
+}
+
+// TestEnvCredentials tests reading credentials from environment variables
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("TEST_BEOSIN_ID", "id")
+	t.Setenv("TEST_BEOSIN_SECRET", "secret")
+
+	creds, err := EnvCredentials("TEST_BEOSIN_ID", "TEST_BEOSIN_SECRET").Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+	if creds != (Credentials{AppID: "id", AppSecret: "secret"}) {
+		t.Errorf("Unexpected credentials: %+v", creds)
+	}
+
+	if _, err := EnvCredentials("TEST_BEOSIN_MISSING", "TEST_BEOSIN_SECRET").Retrieve(context.Background()); err == nil {
+		t.Error("Expected missing variable to fail")
+	}
+}
@@ -0,0 +1,222 @@
+package beosin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strings"
+)
+
+// base58Alphabet is a base58 alphabet with its reverse lookup table
+type base58Alphabet struct {
+	chars string
+	index [256]int8
+}
+
+// newBase58Alphabet creates the lookup table of an alphabet
+func newBase58Alphabet(chars string) *base58Alphabet {
+	a := &base58Alphabet{chars: chars}
+	for i := range a.index {
+		a.index[i] = -1
+	}
+	for i := 0; i < len(chars); i++ {
+		a.index[chars[i]] = int8(i)
+	}
+	return a
+}
+
+// Base58 alphabets used by Bitcoin-derived chains and the XRP Ledger
+var (
+	bitcoinAlphabet = newBase58Alphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	rippleAlphabet  = newBase58Alphabet("rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+)
+
+// decode decodes a base58 string
+func (a *base58Alphabet) decode(s string) ([]byte, bool) {
+	if s == "" {
+		return nil, false
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == a.chars[0] {
+		zeros++
+	}
+
+	// Accumulate the value in little-endian order
+	var value []byte
+	for i := 0; i < len(s); i++ {
+		digit := a.index[s[i]]
+		if digit < 0 {
+			return nil, false
+		}
+		carry := int(digit)
+		for j := range value {
+			carry += int(value[j]) * 58
+			value[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			value = append(value, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	out := make([]byte, zeros, zeros+len(value))
+	for i := len(value) - 1; i >= 0; i-- {
+		out = append(out, value[i])
+	}
+	return out, true
+}
+
+// encode encodes bytes as a base58 string
+func (a *base58Alphabet) encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	// Accumulate the digits in little-endian order
+	var digits []byte
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		for j := range digits {
+			carry += int(digits[j]) << 8
+			digits[j] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < zeros; i++ {
+		sb.WriteByte(a.chars[0])
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		sb.WriteByte(a.chars[digits[i]])
+	}
+	return sb.String()
+}
+
+// decodeCheck decodes a base58check string and returns the payload without the checksum
+func (a *base58Alphabet) decodeCheck(s string) ([]byte, bool) {
+	data, ok := a.decode(s)
+	if !ok || len(data) < 5 {
+		return nil, false
+	}
+	payload, checksum := data[:len(data)-4], data[len(data)-4:]
+	return payload, bytes.Equal(doubleSHA256(payload)[:4], checksum)
+}
+
+// encodeCheck encodes a payload with a base58check checksum
+func (a *base58Alphabet) encodeCheck(payload []byte) string {
+	return a.encode(append(payload[:len(payload):len(payload)], doubleSHA256(payload)[:4]...))
+}
+
+// doubleSHA256 returns SHA-256(SHA-256(data))
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// Checksum constants of the bech32 variants (BIP-173 and BIP-350)
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// bech32Charset is the bech32 data alphabet
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Polymod computes the bech32 checksum polynomial
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32Decode decodes a bech32 or bech32m string and returns the human-readable part,
+// the 5-bit data without checksum and the checksum constant of the variant
+func bech32Decode(s string) (string, []byte, uint32, bool) {
+	if len(s) > 90 || (strings.ToLower(s) != s && strings.ToUpper(s) != s) {
+		return "", nil, 0, false
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, false
+	}
+	hrp := s[:sep]
+
+	data := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		d := strings.IndexByte(bech32Charset, s[i])
+		if d < 0 {
+			return "", nil, 0, false
+		}
+		data = append(data, byte(d))
+	}
+
+	values := make([]byte, 0, len(hrp)*2+1+len(data))
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	values = append(values, data...)
+
+	constant := bech32Polymod(values)
+	if constant != bech32Const && constant != bech32mConst {
+		return "", nil, 0, false
+	}
+	return hrp, data[:len(data)-6], constant, true
+}
+
+// convertBits regroups bits from one word size to another without padding
+func convertBits(data []byte, from, to uint) ([]byte, bool) {
+	var acc, bits uint
+	maxv := uint(1)<<to - 1
+	var out []byte
+	for _, v := range data {
+		acc = acc<<from | uint(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if bits >= from || (acc<<(to-bits))&maxv != 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// crc16XModem computes the CRC-16/XMODEM checksum used by TON addresses
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
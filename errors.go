@@ -1,20 +1,132 @@
 package beosin
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// maxErrorBodySize is the maximum number of body bytes kept in an HTTPError
+const maxErrorBodySize = 1024
 
 // Common error codes from Beosin API
 const (
-	ErrCodeParameterError        = 40001
-	ErrCodePlatformNotSupported  = 40021
-	ErrCodeAddressError          = 40022
-	ErrCodeTxHashError           = 40023
-	ErrCodeTxHashNotExist        = 41023
-	ErrCodeNonERC20NotSupported  = 41024
-	ErrCodeContractNotSupported  = 41026
-	ErrCodeTokenNotInBasket      = 41035
-	ErrCodeTaskExecuting         = 41038
+	ErrCodeParameterError       = 40001
+	ErrCodePlatformNotSupported = 40021
+	ErrCodeAddressError         = 40022
+	ErrCodeTxHashError          = 40023
+	ErrCodeTxHashNotExist       = 41023
+	ErrCodeNonERC20NotSupported = 41024
+	ErrCodeContractNotSupported = 41026
+	ErrCodeTokenNotInBasket     = 41035
+	ErrCodeTaskExecuting        = 41038
+)
+
+// ErrorCategory groups API error codes by how callers are expected to handle them
+type ErrorCategory int
+
+const (
+	// ErrorCategoryUnknown is used for codes that are not in the registry
+	ErrorCategoryUnknown ErrorCategory = iota
+
+	// ErrorCategoryInvalidRequest means the request parameters are malformed
+	ErrorCategoryInvalidRequest
+
+	// ErrorCategoryUnsupported means the chain, token or address type is not supported
+	ErrorCategoryUnsupported
+
+	// ErrorCategoryNotFound means the requested object does not exist
+	ErrorCategoryNotFound
+
+	// ErrorCategoryPending means the result is not ready yet and the request should be repeated later
+	ErrorCategoryPending
+)
+
+// String returns the name of the category
+func (c ErrorCategory) String() string {
+	switch c {
+	case ErrorCategoryInvalidRequest:
+		return "invalid_request"
+	case ErrorCategoryUnsupported:
+		return "unsupported"
+	case ErrorCategoryNotFound:
+		return "not_found"
+	case ErrorCategoryPending:
+		return "pending"
+	}
+	return "unknown"
+}
+
+// ErrorCodeInfo describes a documented Beosin API error code
+type ErrorCodeInfo struct {
+	// Code is the API error code
+	Code int
+
+	// Description is a human-readable description of the code
+	Description string
+
+	// Category is the handling category of the code
+	Category ErrorCategory
+}
+
+// errorCodeRegistry holds all documented Beosin API error codes
+var errorCodeRegistry = map[int]ErrorCodeInfo{
+	ErrCodeParameterError:       {ErrCodeParameterError, "parameter error", ErrorCategoryInvalidRequest},
+	ErrCodePlatformNotSupported: {ErrCodePlatformNotSupported, "platform not supported", ErrorCategoryUnsupported},
+	ErrCodeAddressError:         {ErrCodeAddressError, "invalid address", ErrorCategoryInvalidRequest},
+	ErrCodeTxHashError:          {ErrCodeTxHashError, "invalid transaction hash", ErrorCategoryInvalidRequest},
+	ErrCodeTxHashNotExist:       {ErrCodeTxHashNotExist, "transaction hash does not exist", ErrorCategoryNotFound},
+	ErrCodeNonERC20NotSupported: {ErrCodeNonERC20NotSupported, "non-ERC20 token not supported", ErrorCategoryUnsupported},
+	ErrCodeContractNotSupported: {ErrCodeContractNotSupported, "contract address not supported", ErrorCategoryUnsupported},
+	ErrCodeTokenNotInBasket:     {ErrCodeTokenNotInBasket, "token not in the supported token basket", ErrorCategoryUnsupported},
+	ErrCodeTaskExecuting:        {ErrCodeTaskExecuting, "task is executing, retry later", ErrorCategoryPending},
+}
+
+// LookupErrorCode returns the registry entry for an API error code.
+// Unknown codes are reported with ErrorCategoryUnknown.
+func LookupErrorCode(code int) ErrorCodeInfo {
+	if info, ok := errorCodeRegistry[code]; ok {
+		return info
+	}
+	return ErrorCodeInfo{
+		Code:        code,
+		Description: "unknown error code",
+		Category:    ErrorCategoryUnknown,
+	}
+}
+
+// ErrorCodes returns all documented API error codes ordered by code
+func ErrorCodes() []ErrorCodeInfo {
+	codes := make([]ErrorCodeInfo, 0, len(errorCodeRegistry))
+	for _, info := range errorCodeRegistry {
+		codes = append(codes, info)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].Code < codes[j].Code
+	})
+	return codes
+}
+
+// Sentinel errors for documented API error codes, usable with errors.Is
+var (
+	ErrParameterInvalid     = newSentinelError(ErrCodeParameterError)
+	ErrPlatformNotSupported = newSentinelError(ErrCodePlatformNotSupported)
+	ErrAddressInvalid       = newSentinelError(ErrCodeAddressError)
+	ErrTxHashInvalid        = newSentinelError(ErrCodeTxHashError)
+	ErrTxHashNotExist       = newSentinelError(ErrCodeTxHashNotExist)
+	ErrNonERC20NotSupported = newSentinelError(ErrCodeNonERC20NotSupported)
+	ErrContractNotSupported = newSentinelError(ErrCodeContractNotSupported)
+	ErrTokenNotInBasket     = newSentinelError(ErrCodeTokenNotInBasket)
+	ErrTaskExecuting        = newSentinelError(ErrCodeTaskExecuting)
 )
 
+// newSentinelError creates the sentinel APIError for a registered code
+func newSentinelError(code int) *APIError {
+	return NewAPIError(code, LookupErrorCode(code).Description)
+}
+
 // APIError represents an error returned by the Beosin API
 type APIError struct {
 	Code    int    `json:"code"`
@@ -26,6 +138,22 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("beosin api error: code=%d, message=%s", e.Code, e.Message)
 }
 
+// Is reports whether target is an APIError with the same code
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code == e.Code
+}
+
+// Category returns the handling category of the error code
+func (e *APIError) Category() ErrorCategory {
+	return LookupErrorCode(e.Code).Category
+}
+
+// Description returns the human-readable description of the error code
+func (e *APIError) Description() string {
+	return LookupErrorCode(e.Code).Description
+}
+
 // IsParameterError checks if the error is a parameter error
 func (e *APIError) IsParameterError() bool {
 	return e.Code == ErrCodeParameterError
@@ -51,6 +179,16 @@ func (e *APIError) IsTxHashNotExist() bool {
 	return e.Code == ErrCodeTxHashNotExist
 }
 
+// IsNonERC20NotSupported checks if the token is not an ERC20 token
+func (e *APIError) IsNonERC20NotSupported() bool {
+	return e.Code == ErrCodeNonERC20NotSupported
+}
+
+// IsContractNotSupported checks if the contract address is not supported
+func (e *APIError) IsContractNotSupported() bool {
+	return e.Code == ErrCodeContractNotSupported
+}
+
 // IsTaskExecuting checks if the task is still executing
 func (e *APIError) IsTaskExecuting() bool {
 	return e.Code == ErrCodeTaskExecuting
@@ -68,3 +206,90 @@ func NewAPIError(code int, message string) *APIError {
 		Message: message,
 	}
 }
+
+// HTTPError represents a non-200 HTTP response from the Beosin API
+type HTTPError struct {
+	// StatusCode is the HTTP status code
+	StatusCode int
+
+	// Header contains the response headers
+	Header http.Header
+
+	// Body is the response body, truncated to a reasonable size
+	Body string
+
+	// Endpoint is the API endpoint that was requested
+	Endpoint string
+
+	// apiErr is the API error carried in the body, if any
+	apiErr *APIError
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("beosin http error: status=%d, endpoint=%s, body=%s", e.StatusCode, e.Endpoint, e.Body)
+}
+
+// Unwrap returns the API error carried in the response body, if any
+func (e *HTTPError) Unwrap() error {
+	if e.apiErr == nil {
+		return nil
+	}
+	return e.apiErr
+}
+
+// newHTTPError creates a new HTTPError from a response and its body
+func newHTTPError(endpoint string, resp *http.Response, body []byte) *HTTPError {
+	httpErr := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Endpoint:   endpoint,
+	}
+
+	if len(body) > maxErrorBodySize {
+		httpErr.Body = string(body[:maxErrorBodySize]) + "...(truncated)"
+	} else {
+		httpErr.Body = string(body)
+	}
+
+	var baseResp BaseResponse
+	if err := json.Unmarshal(body, &baseResp); err == nil && baseResp.Code != 0 && !baseResp.IsSuccess() {
+		httpErr.apiErr = NewAPIError(baseResp.Code, baseResp.Msg)
+	}
+
+	return httpErr
+}
+
+// IsRetryable checks if an error returned by the client is likely to succeed on retry
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return isRetryableStatus(httpErr.StatusCode)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false
+	}
+	return isTransientTransportError(err)
+}
+
+// IsAuthError checks if an error returned by the client is caused by invalid credentials
+func IsAuthError(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// IsRateLimited checks if an error returned by the client is caused by rate limiting
+func IsRateLimited(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package beosin
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Operation names of the Client methods, as reported to interceptors
+const (
+	OperationGetAccountBalance                 = "GetAccountBalance"
+	OperationDepositTransactionAssessment      = "DepositTransactionAssessment"
+	OperationWithdrawalTransactionAssessment   = "WithdrawalTransactionAssessment"
+	OperationEOAAddressRiskAssessment          = "EOAAddressRiskAssessment"
+	OperationMaliciousAddressQuery             = "MaliciousAddressQuery"
+	OperationVASPQuery                         = "VASPQuery"
+	OperationV4EOAAddressRiskAssessment        = "V4EOAAddressRiskAssessment"
+	OperationV4DepositTransactionAssessment    = "V4DepositTransactionAssessment"
+	OperationV4WithdrawalTransactionAssessment = "V4WithdrawalTransactionAssessment"
+	OperationBlackAddressScreening             = "BlackAddressScreening"
+)
+
+// CallInfo describes a single Client call as seen by interceptors
+type CallInfo struct {
+	// Operation is the name of the Client method (e.g. OperationVASPQuery)
+	Operation string
+
+	// Endpoint is the API endpoint the call is sent to
+	Endpoint string
+
+	// ChainID is the chain ID of the request, or the platform for black address screening
+	ChainID string
+
+	// Header holds extra headers to add to the outbound HTTP request
+	Header http.Header
+
+	// Start is the time the call started
+	Start time.Time
+
+	// Duration is the time from Start until the response was handled, including retries,
+	// available once the call has completed (zero if an interceptor short-circuited the call)
+	Duration time.Duration
+
+	// Attempts is the number of HTTP attempts made, available once the call has completed
+	Attempts int
+
+	// StatusCode is the HTTP status of the last attempt, available once the call has completed
+	StatusCode int
+
+	// Code is the API code of the response, available once the call has completed
+	Code int
+
+	// RiskLevel is the overall risk level of the response, if it has one
+	RiskLevel RiskLevel
+
+	// CacheHit indicates that the response was served from the cache
+	CacheHit bool
+
+	// Shared indicates that the response was shared by a concurrent identical call
+	Shared bool
+}
+
+// Handler performs a Client call and returns its response
+type Handler func(ctx context.Context, req interface{}) (interface{}, error)
+
+// Interceptor intercepts every Client call, similar to a gRPC unary interceptor.
+// The request and response are the pointer types of the Client method (e.g.
+// *DepositRequest and *TransactionRiskResponse). An interceptor may modify the
+// request, call next to continue the chain or skip it to short-circuit, and
+// inspect or replace the response and error.
+type Interceptor func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error)
+
+// chainInterceptors composes interceptors around the final handler.
+// The first interceptor is the outermost one.
+func chainInterceptors(interceptors []Interceptor, info *CallInfo, final Handler) Handler {
+	handler := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, info, req, next)
+		}
+	}
+	return handler
+}
+
+// requestChainID returns the chain ID or platform of a Client request
+func requestChainID(req interface{}) string {
+	switch r := req.(type) {
+	case *DepositRequest:
+		if r != nil {
+			return r.ChainID
+		}
+	case *WithdrawalRequest:
+		if r != nil {
+			return r.ChainID
+		}
+	case *AddressRiskRequest:
+		if r != nil {
+			return r.ChainID
+		}
+	case *MaliciousAddressRequest:
+		if r != nil {
+			return r.ChainID
+		}
+	case *VASPRequest:
+		if r != nil {
+			return r.ChainID
+		}
+	case *BlackScreeningRequest:
+		if r != nil {
+			return r.Platform
+		}
+	}
+	return ""
+}
+
+// responseRiskLevel returns the overall risk level of a Client response
+func responseRiskLevel(resp interface{}) RiskLevel {
+	switch r := resp.(type) {
+	case *TransactionRiskResponse:
+		if r != nil && r.Data != nil {
+			return r.Data.RiskLevel
+		}
+	case *AddressRiskResponse:
+		if r != nil && r.Data != nil {
+			return r.Data.RiskLevel
+		}
+	case *V4TransactionRiskResponse:
+		if r != nil && r.Data != nil {
+			return r.Data.RiskLevel
+		}
+	case *V4AddressRiskResponse:
+		if r != nil && r.Data != nil {
+			return r.Data.RiskLevel
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package beosin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestInterceptorChain tests interceptor ordering, request mutation and extra headers
+func TestInterceptorChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("Expected tenant header, got %q", r.Header.Get("X-Tenant"))
+		}
+		fmt.Fprintf(w, `{"code":200,"msg":"success","data":{"address":%q,"isVasp":true}}`, r.URL.Query().Get("address"))
+	}))
+	defer server.Close()
+
+	var order []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+			order = append(order, name+":"+info.Operation)
+			return next(ctx, req)
+		}
+	}
+	mutate := func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+		info.Header.Set("X-Tenant", "acme")
+		req.(*VASPRequest).Address = "0xmutated"
+		return next(ctx, req)
+	}
+
+	client := NewClient("id", "secret",
+		WithBaseURL(server.URL),
+		WithInterceptors(record("first"), record("second"), mutate),
+	)
+
+	resp, err := client.VASPQuery(context.Background(), &VASPRequest{ChainID: ChainETH, Address: "0xoriginal"})
+	if err != nil {
+		t.Fatalf("VASPQuery failed: %v", err)
+	}
+	if resp.Data.Address != "0xmutated" {
+		t.Errorf("Expected mutated address, got %s", resp.Data.Address)
+	}
+
+	expected := []string{"first:VASPQuery", "second:VASPQuery"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected order %v, got %v", expected, order)
+	}
+}
+
+// TestInterceptorDuration tests that the call duration is available once next returns
+func TestInterceptorDuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"code":200,"msg":"success","data":{"surplusIntegral":1}}`)
+	}))
+	defer server.Close()
+
+	var start time.Time
+	var duration time.Duration
+	timing := func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+		resp, err := next(ctx, req)
+		start, duration = info.Start, info.Duration
+		return resp, err
+	}
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithInterceptors(timing))
+	if _, err := client.GetAccountBalance(context.Background()); err != nil {
+		t.Fatalf("GetAccountBalance failed: %v", err)
+	}
+	if start.IsZero() || duration < 20*time.Millisecond {
+		t.Errorf("Expected a start time and a duration of at least 20ms, got %v and %s", start, duration)
+	}
+}
+
+// TestInterceptorShortCircuit tests that an interceptor can answer without calling the API
+func TestInterceptorShortCircuit(t *testing.T) {
+	stub := func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+		return &AccountBalanceResponse{
+			BaseResponse: BaseResponse{Code: 200},
+			Data:         &AccountBalanceData{SurplusIntegral: 7},
+		}, nil
+	}
+
+	client := NewClient("id", "secret", WithBaseURL("http://127.0.0.1:0"), WithInterceptors(stub))
+
+	resp, err := client.GetAccountBalance(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccountBalance failed: %v", err)
+	}
+	if resp.Data.SurplusIntegral != 7 {
+		t.Errorf("Expected 7 credits, got %d", resp.Data.SurplusIntegral)
+	}
+}
@@ -0,0 +1,203 @@
+package beosin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// DefaultLogMaxBodySize is the default number of response body bytes included in logs
+const DefaultLogMaxBodySize = 512
+
+// redactedValue replaces secrets in log output
+const redactedValue = "[REDACTED]"
+
+// LogOptions configures structured logging.
+// Addresses and transaction hashes are masked unless explicitly revealed,
+// and the APP-SECRET header is never logged.
+type LogOptions struct {
+	// RevealAddresses disables masking of addresses
+	RevealAddresses bool
+
+	// RevealTxHashes disables masking of transaction hashes
+	RevealTxHashes bool
+
+	// MaxBodySize is the maximum number of response body bytes logged (0 uses the default, negative omits bodies)
+	MaxBodySize int
+}
+
+// redactor masks the addresses and transaction hashes of a request in log output
+type redactor struct {
+	// pairs holds the values to mask, matched ignoring case, and their masked forms
+	pairs [][2]string
+
+	addresses bool
+	hashes    bool
+}
+
+// newRedactor returns a redactor for the sensitive query parameters of a request
+func (o *LogOptions) newRedactor(params url.Values) *redactor {
+	r := &redactor{addresses: !o.RevealAddresses, hashes: !o.RevealTxHashes}
+	add := func(value string) {
+		if value == "" {
+			return
+		}
+		masked := maskValue(value)
+		r.pairs = append(r.pairs, [2]string{value, masked})
+		if escaped := url.QueryEscape(value); escaped != value {
+			r.pairs = append(r.pairs, [2]string{escaped, masked})
+		}
+	}
+
+	if r.addresses {
+		add(params.Get("address"))
+	}
+	if r.hashes {
+		add(params.Get("hash"))
+	}
+	return r
+}
+
+// Replace masks every occurrence of the request's values in s, ignoring case so that
+// values echoed in another case (e.g. checksummed addresses) are masked too
+func (r *redactor) Replace(s string) string {
+	for _, pair := range r.pairs {
+		s = replaceFold(s, pair[0], pair[1])
+	}
+	return s
+}
+
+// body masks a JSON response body for logging: values of address and hash fields are
+// masked wherever they appear, then the request's values are masked in the result
+func (r *redactor) body(body []byte) string {
+	var value any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if (r.addresses || r.hashes) && dec.Decode(&value) == nil {
+		if masked, err := json.Marshal(r.maskFields(value)); err == nil {
+			body = masked
+		}
+	}
+	return r.Replace(string(body))
+}
+
+// error masks an error for logging. The body of an HTTP error may echo addresses and
+// hashes other than the request's own, so it is masked like a response body, or
+// omitted if it is not JSON.
+func (r *redactor) error(err error) string {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Body == "" || !(r.addresses || r.hashes) {
+		return r.Replace(err.Error())
+	}
+	masked := *httpErr
+	masked.Body = redactedValue
+	if json.Valid([]byte(httpErr.Body)) {
+		masked.Body = r.body([]byte(httpErr.Body))
+	}
+	return r.Replace(strings.Replace(err.Error(), httpErr.Error(), masked.Error(), 1))
+}
+
+// maskFields masks the string values of address and hash fields in a decoded JSON value
+func (r *redactor) maskFields(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if s, ok := field.(string); ok && r.sensitiveField(key) {
+				v[key] = maskValue(s)
+				continue
+			}
+			v[key] = r.maskFields(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.maskFields(item)
+		}
+	}
+	return value
+}
+
+// sensitiveField checks if a JSON field holds an address or transaction hash
+// (e.g. address, toAddress, hash, txHash)
+func (r *redactor) sensitiveField(key string) bool {
+	key = strings.ToLower(key)
+	return r.addresses && strings.HasSuffix(key, "address") || r.hashes && strings.HasSuffix(key, "hash")
+}
+
+// replaceFold replaces every occurrence of old in s with replacement, ignoring ASCII case
+func replaceFold(s, old, replacement string) string {
+	if old == "" || len(s) < len(old) {
+		return s
+	}
+	var sb strings.Builder
+	start := 0
+	for i := 0; i+len(old) <= len(s); {
+		if strings.EqualFold(s[i:i+len(old)], old) {
+			sb.WriteString(s[start:i])
+			sb.WriteString(replacement)
+			i += len(old)
+			start = i
+			continue
+		}
+		i++
+	}
+	if start == 0 {
+		return s
+	}
+	sb.WriteString(s[start:])
+	return sb.String()
+}
+
+// truncateBody limits a response body to the configured size for logging
+func (o *LogOptions) truncateBody(body []byte) string {
+	limit := o.MaxBodySize
+	if limit == 0 {
+		limit = DefaultLogMaxBodySize
+	}
+	if limit < 0 {
+		return ""
+	}
+	if len(body) > limit {
+		return string(body[:limit]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// maskValue keeps the beginning and end of a value so log lines can still be correlated
+func maskValue(value string) string {
+	if len(value) <= 12 {
+		return "***"
+	}
+	return value[:6] + "..." + value[len(value)-4:]
+}
+
+// newDebugLogger creates the logger used by WithDebug when no logger is configured
+func newDebugLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// logAttrs writes a log record if the level is enabled
+func (c *client) logAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if !c.options.Logger.Enabled(ctx, level) {
+		return
+	}
+	c.options.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logEnabled checks if the logger records messages at the given level
+func (c *client) logEnabled(ctx context.Context, level slog.Level) bool {
+	return c.options.Logger.Enabled(ctx, level)
+}
+
+// responseCode extracts the API code from a response body for logging
+func responseCode(body []byte) int {
+	var baseResp BaseResponse
+	if err := json.Unmarshal(body, &baseResp); err != nil {
+		return 0
+	}
+	return baseResp.Code
+}
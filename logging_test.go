@@ -0,0 +1,99 @@
+package beosin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLoggingRedaction tests that secrets and addresses are not written to the log
+func TestLoggingRedaction(t *testing.T) {
+	address := "0x901bb9583b24d97e995513c6778dc6888ab6870e"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":200,"msg":"success","data":{"address":%q,"isMalicious":false}}`, address)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("id", "top-secret", WithBaseURL(server.URL), WithLogger(logger))
+
+	_, err := client.MaliciousAddressQuery(context.Background(), &MaliciousAddressRequest{ChainID: ChainETH, Address: address})
+	if err != nil {
+		t.Fatalf("MaliciousAddressQuery failed: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, address) {
+		t.Error("Address was written to the log")
+	}
+	if strings.Contains(output, "top-secret") {
+		t.Error("App secret was written to the log")
+	}
+	if !strings.Contains(output, `"api_code":200`) || !strings.Contains(output, maskValue(address)) {
+		t.Errorf("Expected structured response record, got %s", output)
+	}
+}
+
+// TestLoggingRedactionCaseAndFields tests that addresses echoed in another case and address
+// and hash fields of the response body are masked
+func TestLoggingRedactionCaseAndFields(t *testing.T) {
+	address := "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+	checksummed := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	counterparty := "TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW"
+	txHash := "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":200,"msg":"success","data":{"address":%q,"related":[{"toAddress":%q,"txHash":%q}],"note":"seen %s"}}`,
+			checksummed, counterparty, txHash, checksummed)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithLogger(logger))
+
+	if _, err := client.VASPQuery(context.Background(), &VASPRequest{ChainID: ChainETH, Address: address}); err != nil {
+		t.Fatalf("VASPQuery failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, value := range []string{address, checksummed, counterparty, txHash} {
+		if strings.Contains(output, value) {
+			t.Errorf("%s was written to the log: %s", value, output)
+		}
+	}
+	if !strings.Contains(output, "beosin response") {
+		t.Errorf("Expected a response record, got %s", output)
+	}
+}
+
+// TestLoggingRedactionHTTPError tests that addresses in HTTP error bodies are masked in
+// retry logs
+func TestLoggingRedactionHTTPError(t *testing.T) {
+	counterparty := "TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprintf(w, `{"code":502,"msg":"upstream failed","data":{"toAddress":%q}}`, counterparty)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithLogger(logger),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond}))
+
+	_, err := client.VASPQuery(context.Background(), &VASPRequest{ChainID: ChainETH, Address: "0x1"})
+	if err == nil {
+		t.Fatal("Expected the HTTP error")
+	}
+	output := buf.String()
+	if !strings.Contains(output, "beosin request retry") || strings.Contains(output, counterparty) {
+		t.Errorf("Expected a retry record without the counterparty address, got %s", output)
+	}
+}
@@ -0,0 +1,29 @@
+package beosin
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsCollector receives measurements of Client calls.
+// Implementations must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveCall is called once every Client call has completed with CallInfo.Duration
+	ObserveCall(info *CallInfo, duration time.Duration, err error)
+
+	// SetAccountBalance is called with the remaining credits whenever GetAccountBalance succeeds
+	SetAccountBalance(credits int64)
+}
+
+// metricsInterceptor returns an interceptor that reports every call to the collector
+func metricsInterceptor(collector MetricsCollector) Interceptor {
+	return func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+		resp, err := next(ctx, req)
+		collector.ObserveCall(info, info.Duration, err)
+
+		if balance, ok := resp.(*AccountBalanceResponse); ok && err == nil && balance.Data != nil {
+			collector.SetAccountBalance(balance.Data.SurplusIntegral)
+		}
+		return resp, err
+	}
+}
@@ -1,6 +1,7 @@
 package beosin
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -24,14 +25,63 @@ type Options struct {
 	// AppSecret is the application secret for authentication
 	AppSecret string
 
+	// Credentials supplies the credentials for each request (defaults to AppID and AppSecret)
+	Credentials CredentialsProvider
+
 	// Timeout is the timeout for HTTP requests
 	Timeout time.Duration
 
 	// HTTPClient is the HTTP client to use for requests
 	HTTPClient *http.Client
 
-	// Debug enables debug logging
+	// Debug enables debug logging to stderr when no Logger is set
 	Debug bool
+
+	// Logger receives structured log records (defaults to discarding them)
+	Logger *slog.Logger
+
+	// LogOptions configures redaction and truncation of logged values
+	LogOptions LogOptions
+
+	// Retry is the retry policy for transient failures
+	Retry RetryPolicy
+
+	// OnRetry is called before each retry attempt
+	OnRetry func(RetryEvent)
+
+	// RateLimit is the client-wide rate limit shared by all endpoints
+	RateLimit RateLimit
+
+	// EndpointRateLimits are additional rate limits for individual endpoints
+	EndpointRateLimits map[string]RateLimit
+
+	// RateLimitCodes are API response codes that report throttling. The API documents
+	// none, so by default only HTTP 429 lowers the rate.
+	RateLimitCodes []int
+
+	// Interceptors are called around every Client call, in order
+	Interceptors []Interceptor
+
+	// Metrics receives measurements of every Client call
+	Metrics MetricsCollector
+
+	// Cache stores responses of cacheable endpoints (nil disables caching)
+	Cache Cache
+
+	// CacheOptions configures which responses are cached and for how long
+	CacheOptions CacheOptions
+
+	// CoalesceRequests shares one in-flight HTTP request between concurrent identical calls
+	CoalesceRequests bool
+
+	// CircuitBreaker enables circuit breakers per endpoint group (nil disables them)
+	CircuitBreaker *CircuitBreakerOptions
+
+	// Budget tracks credit usage and enforces credit limits (nil disables it)
+	Budget *Budget
+
+	// ValidateRequests validates and normalizes addresses and transaction hashes before they are sent
+	ValidateRequests bool
 }
 
 // Option is a function that configures Options
@@ -65,6 +115,131 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithCredentialsProvider sets a provider consulted for the credentials of every request,
+// overriding the static application ID and secret
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(o *Options) {
+		o.Credentials = provider
+	}
+}
+
+// WithLogger sets the structured logger used by the client
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// WithLogOptions configures redaction and truncation of logged values
+func WithLogOptions(logOptions LogOptions) Option {
+	return func(o *Options) {
+		o.LogOptions = logOptions
+	}
+}
+
+// WithRetryPolicy sets the retry policy for transient failures
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Options) {
+		o.Retry = policy
+	}
+}
+
+// WithMaxRetries enables retries with the default backoff settings
+func WithMaxRetries(maxRetries int) Option {
+	return func(o *Options) {
+		o.Retry.MaxRetries = maxRetries
+	}
+}
+
+// WithRetryHook sets a function that is called before each retry attempt
+func WithRetryHook(hook func(RetryEvent)) Option {
+	return func(o *Options) {
+		o.OnRetry = hook
+	}
+}
+
+// WithRateLimit limits the rate of requests across all endpoints
+func WithRateLimit(rate float64, burst int) Option {
+	return func(o *Options) {
+		o.RateLimit = RateLimit{Rate: rate, Burst: burst}
+	}
+}
+
+// WithEndpointRateLimit limits the rate of requests to a single endpoint (e.g. EndpointV4Deposit)
+func WithEndpointRateLimit(endpoint string, rate float64, burst int) Option {
+	return func(o *Options) {
+		if o.EndpointRateLimits == nil {
+			o.EndpointRateLimits = make(map[string]RateLimit)
+		}
+		o.EndpointRateLimits[endpoint] = RateLimit{Rate: rate, Burst: burst}
+	}
+}
+
+// WithRateLimitCodes sets API response codes that lower the rate like HTTP 429,
+// e.g. a throttling code returned for a specific plan
+func WithRateLimitCodes(codes ...int) Option {
+	return func(o *Options) {
+		o.RateLimitCodes = append(o.RateLimitCodes, codes...)
+	}
+}
+
+// WithInterceptors appends interceptors that are called around every Client call.
+// The first interceptor registered is the outermost one.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *Options) {
+		o.Interceptors = append(o.Interceptors, interceptors...)
+	}
+}
+
+// WithMetrics sets a collector that receives measurements of every Client call
+func WithMetrics(collector MetricsCollector) Option {
+	return func(o *Options) {
+		o.Metrics = collector
+	}
+}
+
+// WithRequestValidation enables or disables local validation of the addresses and transaction
+// hashes of requests. Invalid requests fail with a *ValidationError without calling the API,
+// and valid values are sent in their canonical form. The caller's request is not modified.
+func WithRequestValidation(enabled bool) Option {
+	return func(o *Options) {
+		o.ValidateRequests = enabled
+	}
+}
+
+// WithBudget enables credit accounting and limits. The budget may be shared by several clients
+// using the same account.
+func WithBudget(budget *Budget) Option {
+	return func(o *Options) {
+		o.Budget = budget
+	}
+}
+
+// WithCache enables response caching for the endpoints configured in cacheOptions
+func WithCache(cache Cache, cacheOptions CacheOptions) Option {
+	return func(o *Options) {
+		o.Cache = cache
+		o.CacheOptions = cacheOptions
+	}
+}
+
+// WithRequestCoalescing enables or disables sharing one in-flight HTTP request between
+// concurrent calls with the same endpoint and normalized parameters. Only the headers
+// added by the interceptors of the first caller are sent.
+func WithRequestCoalescing(enabled bool) Option {
+	return func(o *Options) {
+		o.CoalesceRequests = enabled
+	}
+}
+
+// WithCircuitBreaker enables circuit breakers that fail fast with ErrCircuitOpen after
+// consecutive transport or 5xx failures of an endpoint group
+func WithCircuitBreaker(breakerOptions CircuitBreakerOptions) Option {
+	return func(o *Options) {
+		o.CircuitBreaker = &breakerOptions
+	}
+}
+
 // applyDefaults applies default values to options
 func (o *Options) applyDefaults() {
 	if o.BaseURL == "" {
@@ -78,4 +253,15 @@ func (o *Options) applyDefaults() {
 			Timeout: o.Timeout,
 		}
 	}
+	if o.Credentials == nil {
+		o.Credentials = StaticCredentials(o.AppID, o.AppSecret)
+	}
+	if o.Logger == nil {
+		if o.Debug {
+			o.Logger = newDebugLogger()
+		} else {
+			o.Logger = slog.New(slog.DiscardHandler)
+		}
+	}
+	o.Retry.applyDefaults()
 }
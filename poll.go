@@ -0,0 +1,103 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPollMaxWait is the default maximum time to wait for a running assessment
+const DefaultPollMaxWait = 2 * time.Minute
+
+// ErrPollTimeout is returned when an assessment is still executing after the maximum wait
+var ErrPollTimeout = errors.New("beosin: assessment still executing")
+
+// PollPolicy configures how assessments that are still executing are re-requested
+type PollPolicy struct {
+	// Intervals is the schedule of waits between polls; the last interval repeats
+	Intervals []time.Duration
+
+	// MaxWait is the maximum total time to wait for a result (0 waits until the context is done)
+	MaxWait time.Duration
+}
+
+// DefaultPollPolicy returns a poll policy with sensible defaults
+func DefaultPollPolicy() PollPolicy {
+	return PollPolicy{
+		Intervals: []time.Duration{time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second},
+		MaxWait:   DefaultPollMaxWait,
+	}
+}
+
+// interval returns the wait after the given poll (starting at 1)
+func (p *PollPolicy) interval(poll int) time.Duration {
+	if len(p.Intervals) == 0 {
+		return time.Second
+	}
+	if poll > len(p.Intervals) {
+		return p.Intervals[len(p.Intervals)-1]
+	}
+	return p.Intervals[poll-1]
+}
+
+// WaitDepositTransactionAssessment performs a deposit assessment, polling while the task is executing.
+// It returns the final response and the number of requests that were made.
+func WaitDepositTransactionAssessment(ctx context.Context, c Client, req *DepositRequest, policy PollPolicy) (*TransactionRiskResponse, int, error) {
+	return poll(ctx, policy, func(ctx context.Context) (*TransactionRiskResponse, error) {
+		return c.DepositTransactionAssessment(ctx, req)
+	})
+}
+
+// WaitWithdrawalTransactionAssessment performs a withdrawal assessment, polling while the task is executing.
+// It returns the final response and the number of requests that were made.
+func WaitWithdrawalTransactionAssessment(ctx context.Context, c Client, req *WithdrawalRequest, policy PollPolicy) (*TransactionRiskResponse, int, error) {
+	return poll(ctx, policy, func(ctx context.Context) (*TransactionRiskResponse, error) {
+		return c.WithdrawalTransactionAssessment(ctx, req)
+	})
+}
+
+// WaitV4DepositTransactionAssessment performs a V4 deposit assessment, polling while the task is executing.
+// It returns the final response and the number of requests that were made.
+func WaitV4DepositTransactionAssessment(ctx context.Context, c Client, req *DepositRequest, policy PollPolicy) (*V4TransactionRiskResponse, int, error) {
+	return poll(ctx, policy, func(ctx context.Context) (*V4TransactionRiskResponse, error) {
+		return c.V4DepositTransactionAssessment(ctx, req)
+	})
+}
+
+// WaitV4WithdrawalTransactionAssessment performs a V4 withdrawal assessment, polling while the task is executing.
+// It returns the final response and the number of requests that were made.
+func WaitV4WithdrawalTransactionAssessment(ctx context.Context, c Client, req *WithdrawalRequest, policy PollPolicy) (*V4TransactionRiskResponse, int, error) {
+	return poll(ctx, policy, func(ctx context.Context) (*V4TransactionRiskResponse, error) {
+		return c.V4WithdrawalTransactionAssessment(ctx, req)
+	})
+}
+
+// poll calls fn until it returns something other than a task executing error
+func poll[T any](ctx context.Context, policy PollPolicy, fn func(context.Context) (T, error)) (T, int, error) {
+	parent := ctx
+	if policy.MaxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.MaxWait)
+		defer cancel()
+	}
+
+	for polls := 1; ; polls++ {
+		resp, err := fn(ctx)
+		if err != nil && ctx.Err() != nil && parent.Err() == nil {
+			// The request was cut short by MaxWait rather than by the caller
+			return resp, polls, fmt.Errorf("%w after %d polls: %w", ErrPollTimeout, polls, err)
+		}
+		var apiErr *APIError
+		if err == nil || !errors.As(err, &apiErr) || !apiErr.IsTaskExecuting() {
+			return resp, polls, err
+		}
+
+		if sleepErr := sleepContext(ctx, policy.interval(polls)); sleepErr != nil {
+			if parent.Err() == nil {
+				return resp, polls, fmt.Errorf("%w after %d polls: %w", ErrPollTimeout, polls, err)
+			}
+			return resp, polls, sleepErr
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPoolBalanceRefreshInterval is the default interval between account balance refreshes
+	DefaultPoolBalanceRefreshInterval = 5 * time.Minute
+
+	// DefaultPoolExhaustedCooldown is the default time an exhausted account is skipped
+	DefaultPoolExhaustedCooldown = time.Hour
+
+	// DefaultPoolBalanceRefreshTimeout is the default timeout of a background balance refresh
+	DefaultPoolBalanceRefreshTimeout = 30 * time.Second
+)
+
+// ErrPoolExhausted is returned when no account of a pool is available
+var ErrPoolExhausted = errors.New("beosin: all pool accounts are exhausted")
+
+// PoolStrategy selects the account that serves a call
+type PoolStrategy int
+
+const (
+	// PoolRoundRobin cycles through the available accounts
+	PoolRoundRobin PoolStrategy = iota
+
+	// PoolWeighted distributes calls proportionally to the account weights
+	PoolWeighted
+
+	// PoolMostCredits prefers the account with the most remaining credits
+	PoolMostCredits
+)
+
+// PoolAccount is an account of a pool
+type PoolAccount struct {
+	// Name identifies the account in events and status reports
+	Name string
+
+	// Client is the client using the account's credentials
+	Client Client
+
+	// Weight is the relative share of calls for PoolWeighted (defaults to 1)
+	Weight int
+}
+
+// PoolEvent reports which account served a call
+type PoolEvent struct {
+	// Account is the name of the account that served the call
+	Account string
+
+	// Operation is the name of the Client method
+	Operation string
+
+	// Request is the request of the call
+	Request interface{}
+
+	// Err is the error of the call, if any
+	Err error
+}
+
+// PoolAccountStatus is a snapshot of the state of a pool account
+type PoolAccountStatus struct {
+	// Name is the name of the account
+	Name string
+
+	// Exhausted indicates that the account is currently skipped
+	Exhausted bool
+
+	// Credits is the last known number of remaining credits
+	Credits int64
+
+	// EquityEnd is the last known end of the account's equity period
+	EquityEnd time.Time
+
+	// BalanceUpdated is when the balance was last refreshed (zero if never)
+	BalanceUpdated time.Time
+}
+
+// PoolOptions configures a pool
+type PoolOptions struct {
+	// Strategy selects the account that serves a call
+	Strategy PoolStrategy
+
+	// BalanceRefreshInterval is how often balances are refreshed in the background
+	BalanceRefreshInterval time.Duration
+
+	// BalanceRefreshTimeout bounds a background refresh of all balances
+	BalanceRefreshTimeout time.Duration
+
+	// ExhaustedCooldown is how long an exhausted account is skipped before it is tried again
+	ExhaustedCooldown time.Duration
+
+	// IsExhausted reports whether an error means the account has run out of quota
+	// (defaults to HTTP 402 responses). Auth errors are left to the credentials
+	// refresh of the account's client and never exhaust an account.
+	IsExhausted func(error) bool
+
+	// OnServed is called after each call with the account that served it
+	OnServed func(PoolEvent)
+}
+
+// poolMember holds the state of a pool account
+type poolMember struct {
+	account        PoolAccount
+	exhaustedUntil time.Time
+	credits        int64
+	equityEnd      time.Time
+	balanceUpdated time.Time
+	balanceChecked time.Time
+	currentWeight  int
+}
+
+// Pool is a Client spreading calls over several accounts and failing over
+// when an account runs out of credits or its equity period ends
+type Pool struct {
+	options PoolOptions
+
+	mu         sync.Mutex
+	members    []*poolMember
+	next       int
+	refreshing bool
+}
+
+// Verify interface compliance
+var _ Client = (*Pool)(nil)
+
+// NewPool creates a pool over the given accounts
+func NewPool(accounts []PoolAccount, options PoolOptions) (*Pool, error) {
+	if len(accounts) == 0 {
+		return nil, errors.New("beosin: pool requires at least one account")
+	}
+	if options.BalanceRefreshInterval <= 0 {
+		options.BalanceRefreshInterval = DefaultPoolBalanceRefreshInterval
+	}
+	if options.BalanceRefreshTimeout <= 0 {
+		options.BalanceRefreshTimeout = DefaultPoolBalanceRefreshTimeout
+	}
+	if options.ExhaustedCooldown <= 0 {
+		options.ExhaustedCooldown = DefaultPoolExhaustedCooldown
+	}
+	if options.IsExhausted == nil {
+		options.IsExhausted = isQuotaError
+	}
+
+	p := &Pool{options: options}
+	for _, account := range accounts {
+		if account.Client == nil {
+			return nil, fmt.Errorf("beosin: pool account %q has no client", account.Name)
+		}
+		if account.Weight <= 0 {
+			account.Weight = 1
+		}
+		p.members = append(p.members, &poolMember{account: account})
+	}
+	return p, nil
+}
+
+// isQuotaError checks if an error indicates that an account has run out of quota.
+// The API documents no codes for exhausted balances or expired equity periods, so
+// those are detected from the account balance instead.
+func isQuotaError(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusPaymentRequired
+}
+
+// Accounts returns the status of all accounts
+func (p *Pool) Accounts() []PoolAccountStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]PoolAccountStatus, len(p.members))
+	for i, m := range p.members {
+		statuses[i] = PoolAccountStatus{
+			Name:           m.account.Name,
+			Exhausted:      !m.available(now),
+			Credits:        m.credits,
+			EquityEnd:      m.equityEnd,
+			BalanceUpdated: m.balanceUpdated,
+		}
+	}
+	return statuses
+}
+
+// RefreshBalances queries the balance of every account. Accounts without credits or
+// with an expired equity period are skipped until a later balance shows otherwise.
+func (p *Pool) RefreshBalances(ctx context.Context) error {
+	var errs []error
+	for _, m := range p.members {
+		resp, err := m.account.Client.GetAccountBalance(ctx)
+		if err != nil {
+			// The attempt still counts, so a failing account is not queried on every call
+			p.markChecked(m)
+			errs = append(errs, fmt.Errorf("account %s: %w", m.account.Name, err))
+			continue
+		}
+		if resp.Data != nil {
+			p.updateBalance(m, resp.Data)
+		} else {
+			p.markChecked(m)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateBalance stores an account balance
+func (p *Pool) updateBalance(m *poolMember, data *AccountBalanceData) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	m.credits = data.SurplusIntegral
+	m.equityEnd = unixTime(data.EquityEndDate)
+	m.balanceUpdated = now
+	m.balanceChecked = now
+}
+
+// markChecked records a balance query that returned no balance
+func (p *Pool) markChecked(m *poolMember) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	m.balanceChecked = time.Now()
+}
+
+// unixTime converts a Unix timestamp in seconds or milliseconds to a time
+func unixTime(ts int64) time.Time {
+	switch {
+	case ts <= 0:
+		return time.Time{}
+	case ts > 1e12:
+		return time.UnixMilli(ts)
+	}
+	return time.Unix(ts, 0)
+}
+
+// available checks if the account may serve calls: it is not cooling down after a
+// quota error and its last known balance has credits and an unexpired equity period
+func (m *poolMember) available(now time.Time) bool {
+	if now.Before(m.exhaustedUntil) {
+		return false
+	}
+	if m.balanceUpdated.IsZero() {
+		return true
+	}
+	return m.credits > 0 && (m.equityEnd.IsZero() || !now.After(m.equityEnd))
+}
+
+// pick selects an available account that has not been tried yet for the call
+func (p *Pool) pick(tried map[*poolMember]bool) *poolMember {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var candidates []*poolMember
+	for _, m := range p.members {
+		if !tried[m] && m.available(now) {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	switch p.options.Strategy {
+	case PoolWeighted:
+		// Smooth weighted round-robin
+		total := 0
+		var best *poolMember
+		for _, m := range candidates {
+			m.currentWeight += m.account.Weight
+			total += m.account.Weight
+			if best == nil || m.currentWeight > best.currentWeight {
+				best = m
+			}
+		}
+		best.currentWeight -= total
+		return best
+	case PoolMostCredits:
+		best := candidates[0]
+		for _, m := range candidates[1:] {
+			if m.credits > best.credits {
+				best = m
+			}
+		}
+		return best
+	}
+
+	m := candidates[p.next%len(candidates)]
+	p.next++
+	return m
+}
+
+// refreshIfStale starts a background refresh when a balance has not been checked
+// within the refresh interval
+func (p *Pool) refreshIfStale() {
+	p.mu.Lock()
+	stale := false
+	for _, m := range p.members {
+		if time.Since(m.balanceChecked) >= p.options.BalanceRefreshInterval {
+			stale = true
+			break
+		}
+	}
+	if !stale || p.refreshing {
+		p.mu.Unlock()
+		return
+	}
+	p.refreshing = true
+	p.mu.Unlock()
+
+	// Calls are routed with the last known balances while the refresh runs, and
+	// balance failures are not fatal. The refresh belongs to the pool, not to the
+	// call that triggered it, so it does not inherit the call's context values.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.options.BalanceRefreshTimeout)
+		defer cancel()
+		_ = p.RefreshBalances(ctx)
+
+		p.mu.Lock()
+		p.refreshing = false
+		p.mu.Unlock()
+	}()
+}
+
+// markExhausted skips an account until the cooldown has passed
+func (p *Pool) markExhausted(m *poolMember) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	m.exhaustedUntil = time.Now().Add(p.options.ExhaustedCooldown)
+}
+
+// poolCall routes a call to an account, failing over to the next account on quota errors
+func poolCall[Resp any](ctx context.Context, p *Pool, operation string, req interface{}, fn func(Client) (Resp, error)) (Resp, error) {
+	p.refreshIfStale()
+
+	tried := make(map[*poolMember]bool)
+	var lastErr error
+	for {
+		m := p.pick(tried)
+		if m == nil {
+			var zero Resp
+			if lastErr != nil {
+				return zero, fmt.Errorf("%w: %w", ErrPoolExhausted, lastErr)
+			}
+			return zero, ErrPoolExhausted
+		}
+		tried[m] = true
+
+		resp, err := fn(m.account.Client)
+		if err != nil && p.options.IsExhausted(err) {
+			p.markExhausted(m)
+			lastErr = err
+			continue
+		}
+
+		if balance, ok := any(resp).(*AccountBalanceResponse); ok && err == nil && balance.Data != nil {
+			p.updateBalance(m, balance.Data)
+		}
+		if p.options.OnServed != nil {
+			p.options.OnServed(PoolEvent{Account: m.account.Name, Operation: operation, Request: req, Err: err})
+		}
+		return resp, err
+	}
+}
+
+// GetAccountBalance queries the balance of the account selected by the pool
+func (p *Pool) GetAccountBalance(ctx context.Context) (*AccountBalanceResponse, error) {
+	return poolCall(ctx, p, OperationGetAccountBalance, nil, func(c Client) (*AccountBalanceResponse, error) {
+		return c.GetAccountBalance(ctx)
+	})
+}
+
+// DepositTransactionAssessment performs risk assessment on deposit transactions
+func (p *Pool) DepositTransactionAssessment(ctx context.Context, req *DepositRequest) (*TransactionRiskResponse, error) {
+	return poolCall(ctx, p, OperationDepositTransactionAssessment, req, func(c Client) (*TransactionRiskResponse, error) {
+		return c.DepositTransactionAssessment(ctx, req)
+	})
+}
+
+// WithdrawalTransactionAssessment performs risk assessment on withdrawal transactions
+func (p *Pool) WithdrawalTransactionAssessment(ctx context.Context, req *WithdrawalRequest) (*TransactionRiskResponse, error) {
+	return poolCall(ctx, p, OperationWithdrawalTransactionAssessment, req, func(c Client) (*TransactionRiskResponse, error) {
+		return c.WithdrawalTransactionAssessment(ctx, req)
+	})
+}
+
+// EOAAddressRiskAssessment performs risk assessment on EOA addresses
+func (p *Pool) EOAAddressRiskAssessment(ctx context.Context, req *AddressRiskRequest) (*AddressRiskResponse, error) {
+	return poolCall(ctx, p, OperationEOAAddressRiskAssessment, req, func(c Client) (*AddressRiskResponse, error) {
+		return c.EOAAddressRiskAssessment(ctx, req)
+	})
+}
+
+// MaliciousAddressQuery queries if an address is malicious
+func (p *Pool) MaliciousAddressQuery(ctx context.Context, req *MaliciousAddressRequest) (*MaliciousAddressResponse, error) {
+	return poolCall(ctx, p, OperationMaliciousAddressQuery, req, func(c Client) (*MaliciousAddressResponse, error) {
+		return c.MaliciousAddressQuery(ctx, req)
+	})
+}
+
+// VASPQuery queries if an address is a VASP entity
+func (p *Pool) VASPQuery(ctx context.Context, req *VASPRequest) (*VASPResponse, error) {
+	return poolCall(ctx, p, OperationVASPQuery, req, func(c Client) (*VASPResponse, error) {
+		return c.VASPQuery(ctx, req)
+	})
+}
+
+// V4EOAAddressRiskAssessment performs V4 risk assessment on EOA addresses
+func (p *Pool) V4EOAAddressRiskAssessment(ctx context.Context, req *AddressRiskRequest) (*V4AddressRiskResponse, error) {
+	return poolCall(ctx, p, OperationV4EOAAddressRiskAssessment, req, func(c Client) (*V4AddressRiskResponse, error) {
+		return c.V4EOAAddressRiskAssessment(ctx, req)
+	})
+}
+
+// V4DepositTransactionAssessment performs V4 risk assessment on deposit transactions
+func (p *Pool) V4DepositTransactionAssessment(ctx context.Context, req *DepositRequest) (*V4TransactionRiskResponse, error) {
+	return poolCall(ctx, p, OperationV4DepositTransactionAssessment, req, func(c Client) (*V4TransactionRiskResponse, error) {
+		return c.V4DepositTransactionAssessment(ctx, req)
+	})
+}
+
+// V4WithdrawalTransactionAssessment performs V4 risk assessment on withdrawal transactions
+func (p *Pool) V4WithdrawalTransactionAssessment(ctx context.Context, req *WithdrawalRequest) (*V4TransactionRiskResponse, error) {
+	return poolCall(ctx, p, OperationV4WithdrawalTransactionAssessment, req, func(c Client) (*V4TransactionRiskResponse, error) {
+		return c.V4WithdrawalTransactionAssessment(ctx, req)
+	})
+}
+
+// BlackAddressScreening performs black address screening
+func (p *Pool) BlackAddressScreening(ctx context.Context, req *BlackScreeningRequest) (*BlackScreeningResponse, error) {
+	return poolCall(ctx, p, OperationBlackAddressScreening, req, func(c Client) (*BlackScreeningResponse, error) {
+		return c.BlackAddressScreening(ctx, req)
+	})
+}
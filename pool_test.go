@@ -0,0 +1,242 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPoolTestServer starts a server reporting the given credits that fails VASP queries with
+// the given status code (0 for success)
+func newPoolTestServer(t *testing.T, credits int64, status int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointAccountBalance {
+			fmt.Fprintf(w, `{"code":200,"msg":"success","data":{"surplusIntegral":%d,"equityEndDate":0}}`, credits)
+			return
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"isVasp":false}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestPoolFailover tests that an account failing with a quota error is marked exhausted
+// and the call is served by the next account
+func TestPoolFailover(t *testing.T) {
+	primary := newPoolTestServer(t, 10, http.StatusPaymentRequired)
+	backup := newPoolTestServer(t, 10, 0)
+
+	var served []string
+	pool, err := NewPool([]PoolAccount{
+		{Name: "primary", Client: NewClient("a", "a", WithBaseURL(primary.URL))},
+		{Name: "backup", Client: NewClient("b", "b", WithBaseURL(backup.URL))},
+	}, PoolOptions{
+		OnServed: func(e PoolEvent) { served = append(served, e.Account) },
+	})
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+
+	ctx := context.Background()
+	req := &VASPRequest{ChainID: ChainETH, Address: "0x1"}
+	for i := 0; i < 3; i++ {
+		if _, err := pool.VASPQuery(ctx, req); err != nil {
+			t.Fatalf("Call %d failed: %v", i, err)
+		}
+	}
+	if len(served) != 3 || served[0] != "backup" || served[2] != "backup" {
+		t.Errorf("Expected all calls to be served by backup, got %v", served)
+	}
+
+	statuses := pool.Accounts()
+	if !statuses[0].Exhausted || statuses[1].Exhausted {
+		t.Errorf("Expected only primary to be exhausted, got %+v", statuses)
+	}
+
+	only, _ := NewPool([]PoolAccount{
+		{Name: "primary", Client: NewClient("a", "a", WithBaseURL(primary.URL))},
+	}, PoolOptions{})
+	_, err = only.VASPQuery(ctx, req)
+	var httpErr *HTTPError
+	if !errors.Is(err, ErrPoolExhausted) || !errors.As(err, &httpErr) {
+		t.Errorf("Expected pool exhausted error wrapping the HTTP error, got %v", err)
+	}
+}
+
+// TestPoolAuthError tests that an auth error is returned without taking the account out of service
+func TestPoolAuthError(t *testing.T) {
+	forbidden := newPoolTestServer(t, 10, http.StatusForbidden)
+	pool, _ := NewPool([]PoolAccount{
+		{Name: "rotating", Client: NewClient("a", "a", WithBaseURL(forbidden.URL))},
+	}, PoolOptions{})
+
+	_, err := pool.VASPQuery(context.Background(), &VASPRequest{ChainID: ChainETH, Address: "0x1"})
+	if !IsAuthError(err) || errors.Is(err, ErrPoolExhausted) {
+		t.Errorf("Expected the auth error, got %v", err)
+	}
+	if statuses := pool.Accounts(); statuses[0].Exhausted {
+		t.Errorf("Expected the account to stay in service, got %+v", statuses[0])
+	}
+}
+
+// TestPoolMostCredits tests that calls are routed to the account with the most credits
+// and that accounts without credits are skipped
+func TestPoolMostCredits(t *testing.T) {
+	empty := newPoolTestServer(t, 0, 0)
+	small := newPoolTestServer(t, 100, 0)
+	large := newPoolTestServer(t, 5000, 0)
+
+	var served []string
+	pool, err := NewPool([]PoolAccount{
+		{Name: "empty", Client: NewClient("a", "a", WithBaseURL(empty.URL))},
+		{Name: "small", Client: NewClient("b", "b", WithBaseURL(small.URL))},
+		{Name: "large", Client: NewClient("c", "c", WithBaseURL(large.URL))},
+	}, PoolOptions{
+		Strategy: PoolMostCredits,
+		OnServed: func(e PoolEvent) { served = append(served, e.Account) },
+	})
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := pool.RefreshBalances(ctx); err != nil {
+		t.Fatalf("RefreshBalances failed: %v", err)
+	}
+	if _, err := pool.VASPQuery(ctx, &VASPRequest{ChainID: ChainETH, Address: "0x1"}); err != nil {
+		t.Fatalf("VASPQuery failed: %v", err)
+	}
+	if len(served) != 1 || served[0] != "large" {
+		t.Errorf("Expected the call to be served by large, got %v", served)
+	}
+
+	statuses := pool.Accounts()
+	if !statuses[0].Exhausted || statuses[2].Credits != 5000 {
+		t.Errorf("Unexpected account statuses: %+v", statuses)
+	}
+}
+
+// TestPoolWeighted tests that calls are distributed according to the account weights
+func TestPoolWeighted(t *testing.T) {
+	a := newPoolTestServer(t, 10, 0)
+	b := newPoolTestServer(t, 10, 0)
+
+	counts := make(map[string]int)
+	pool, _ := NewPool([]PoolAccount{
+		{Name: "a", Client: NewClient("a", "a", WithBaseURL(a.URL)), Weight: 3},
+		{Name: "b", Client: NewClient("b", "b", WithBaseURL(b.URL)), Weight: 1},
+	}, PoolOptions{
+		Strategy: PoolWeighted,
+		OnServed: func(e PoolEvent) { counts[e.Account]++ },
+	})
+
+	for i := 0; i < 8; i++ {
+		pool.VASPQuery(context.Background(), &VASPRequest{ChainID: ChainETH, Address: "0x1"})
+	}
+	if counts["a"] != 6 || counts["b"] != 2 {
+		t.Errorf("Expected a 6:2 split, got %v", counts)
+	}
+}
+
+// TestPoolBalanceExhaustion tests that round-robin skips an account whose equity period
+// has ended and that a failing balance query is not repeated on every call
+func TestPoolBalanceExhaustion(t *testing.T) {
+	expired := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ended := time.Now().Add(-time.Hour).UnixMilli()
+		fmt.Fprintf(w, `{"code":200,"msg":"success","data":{"surplusIntegral":10,"equityEndDate":%d}}`, ended)
+	}))
+	defer expired.Close()
+	var balanceCalls atomic.Int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointAccountBalance {
+			balanceCalls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"isVasp":false}}`))
+	}))
+	defer failing.Close()
+
+	var served []string
+	pool, _ := NewPool([]PoolAccount{
+		{Name: "expired", Client: NewClient("a", "a", WithBaseURL(expired.URL))},
+		{Name: "failing", Client: NewClient("b", "b", WithBaseURL(failing.URL))},
+	}, PoolOptions{
+		OnServed: func(e PoolEvent) { served = append(served, e.Account) },
+	})
+
+	ctx := context.Background()
+	if err := pool.RefreshBalances(ctx); err == nil {
+		t.Fatal("Expected the failing balance query to be reported")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := pool.VASPQuery(ctx, &VASPRequest{ChainID: ChainETH, Address: "0x1"}); err != nil {
+			t.Fatalf("Call %d failed: %v", i, err)
+		}
+	}
+	if len(served) != 3 || served[0] != "failing" || served[2] != "failing" {
+		t.Errorf("Expected all calls to skip the expired account, got %v", served)
+	}
+	if n := balanceCalls.Load(); n != 1 {
+		t.Errorf("Expected one balance query after a failure, got %d", n)
+	}
+	if statuses := pool.Accounts(); !statuses[0].Exhausted || statuses[1].Exhausted {
+		t.Errorf("Unexpected account statuses: %+v", statuses)
+	}
+}
+
+// TestPoolBackgroundRefresh tests that a stalled balance query does not block later
+// refreshes and that refreshes do not run with the triggering call's context
+func TestPoolBackgroundRefresh(t *testing.T) {
+	var balanceCalls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointAccountBalance {
+			if balanceCalls.Add(1) == 1 {
+				<-release
+			}
+			w.Write([]byte(`{"code":200,"msg":"success","data":{"surplusIntegral":10}}`))
+			return
+		}
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"isVasp":false}}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	type tenantKey struct{}
+	var leaked atomic.Bool
+	recordTenant := func(ctx context.Context, info *CallInfo, req interface{}, next Handler) (interface{}, error) {
+		if info.Operation == OperationGetAccountBalance && ctx.Value(tenantKey{}) != nil {
+			leaked.Store(true)
+		}
+		return next(ctx, req)
+	}
+	pool, _ := NewPool([]PoolAccount{
+		{Name: "a", Client: NewClient("a", "a", WithBaseURL(server.URL), WithInterceptors(recordTenant))},
+	}, PoolOptions{BalanceRefreshInterval: time.Nanosecond, BalanceRefreshTimeout: 20 * time.Millisecond})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "tenant")
+	req := &VASPRequest{ChainID: ChainETH, Address: "0x1"}
+	deadline := time.Now().Add(2 * time.Second)
+	for balanceCalls.Load() < 2 && time.Now().Before(deadline) {
+		if _, err := pool.VASPQuery(ctx, req); err != nil {
+			t.Fatalf("VASPQuery failed: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := balanceCalls.Load(); n < 2 {
+		t.Errorf("Expected a new refresh after the stalled one timed out, got %d balance queries", n)
+	}
+	if leaked.Load() {
+		t.Error("Expected the refresh not to carry the call's context values")
+	}
+}
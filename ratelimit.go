@@ -0,0 +1,208 @@
+package beosin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitMinFactor is the lowest fraction of the configured rate an adaptive limiter drops to
+	rateLimitMinFactor = 0.1
+
+	// rateLimitRecoverySteps is the number of successful requests needed to recover from a throttle
+	rateLimitRecoverySteps = 20
+)
+
+// RateLimit configures a token bucket rate limit
+type RateLimit struct {
+	// Rate is the number of requests allowed per second (0 disables the limit)
+	Rate float64
+
+	// Burst is the maximum number of requests allowed at once
+	Burst int
+}
+
+// rateLimiter is a token bucket that lowers its rate when the API reports throttling
+// and recovers gradually on success
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  float64
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a rate limiter, or nil if the limit is disabled
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		limit:  limit.Rate,
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a request may be sent or the context is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if err := sleepContext(ctx, l.reserve()); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long to wait until it is available
+func (l *rateLimiter) reserve() time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token so that other callers are not delayed
+func (l *rateLimiter) cancel() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
+
+// refill adds the tokens accumulated since the last update
+func (l *rateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// throttle halves the current rate after the API reported rate limiting and drains
+// the burst so the next requests are paced at the lower rate
+func (l *rateLimiter) throttle() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if l.tokens > 0 {
+		l.tokens = 0
+	}
+	l.rate /= 2
+	if minRate := l.limit * rateLimitMinFactor; l.rate < minRate {
+		l.rate = minRate
+	}
+}
+
+// recover raises the current rate back towards the configured limit
+func (l *rateLimiter) recover() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate >= l.limit {
+		return
+	}
+	l.refill(time.Now())
+	l.rate += l.limit / rateLimitRecoverySteps
+	if l.rate > l.limit {
+		l.rate = l.limit
+	}
+}
+
+// rateLimiters holds the global and per-endpoint rate limiters of a client
+type rateLimiters struct {
+	global    *rateLimiter
+	endpoints map[string]*rateLimiter
+	codes     []int
+}
+
+// newRateLimiters creates the rate limiters configured in options
+func newRateLimiters(o *Options) *rateLimiters {
+	limiters := &rateLimiters{
+		global:    newRateLimiter(o.RateLimit),
+		endpoints: make(map[string]*rateLimiter),
+		codes:     o.RateLimitCodes,
+	}
+	for endpoint, limit := range o.EndpointRateLimits {
+		if limiter := newRateLimiter(limit); limiter != nil {
+			limiters.endpoints[endpoint] = limiter
+		}
+	}
+	return limiters
+}
+
+// wait blocks until both the global and the endpoint limiter allow a request. Both tokens
+// are reserved up front and returned together if the context is done first, so a
+// canceled call never uses up a token of the other limiter.
+func (r *rateLimiters) wait(ctx context.Context, endpoint string) error {
+	limiter := r.endpoints[endpoint]
+	delay := max(r.global.reserve(), limiter.reserve())
+	if err := sleepContext(ctx, delay); err != nil {
+		r.global.cancel()
+		limiter.cancel()
+		return err
+	}
+	return nil
+}
+
+// observe adapts the limiters to the outcome of a request. The error includes API errors
+// carried in successful HTTP responses, see attemptError.
+func (r *rateLimiters) observe(endpoint string, err error) {
+	if r.throttled(err) {
+		r.global.throttle()
+		r.endpoints[endpoint].throttle()
+		return
+	}
+	if err == nil {
+		r.global.recover()
+		r.endpoints[endpoint].recover()
+	}
+}
+
+// throttled checks if an error reports throttling: an HTTP 429 or one of the
+// configured rate limiting API codes
+func (r *rateLimiters) throttled(err error) bool {
+	if IsRateLimited(err) {
+		return true
+	}
+	var apiErr *APIError
+	return len(r.codes) > 0 && errors.As(err, &apiErr) && slices.Contains(r.codes, apiErr.Code)
+}
+
+// attemptError returns the error of an attempt, including an API error carried in the
+// body of a successful HTTP response
+func attemptError(body []byte, err error) error {
+	if err != nil || body == nil {
+		return err
+	}
+	var baseResp BaseResponse
+	if json.Unmarshal(body, &baseResp) != nil || baseResp.IsSuccess() {
+		return nil
+	}
+	return NewAPIError(baseResp.Code, baseResp.Msg)
+}
@@ -0,0 +1,114 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestRateLimiterWait tests that the token bucket spaces out requests
+func TestRateLimiterWait(t *testing.T) {
+	limiter := newRateLimiter(RateLimit{Rate: 100, Burst: 1})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := limiter.wait(ctx); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Expected requests to be spaced out, took %s", elapsed)
+	}
+}
+
+// TestRateLimiterAdapts tests that the rate drops on throttling and recovers on success
+func TestRateLimiterAdapts(t *testing.T) {
+	limiter := newRateLimiter(RateLimit{Rate: 10, Burst: 1})
+
+	limiter.throttle()
+	if limiter.rate != 5 {
+		t.Errorf("Expected rate 5 after throttle, got %v", limiter.rate)
+	}
+	if limiter.tokens > 0 {
+		t.Errorf("Expected the burst to be drained after throttle, got %v tokens", limiter.tokens)
+	}
+
+	for i := 0; i < rateLimitRecoverySteps; i++ {
+		limiter.recover()
+	}
+	if limiter.rate != 10 {
+		t.Errorf("Expected rate to recover to 10, got %v", limiter.rate)
+	}
+}
+
+// TestRateLimiterContextCancel tests that waiting stops when the context is done
+func TestRateLimiterContextCancel(t *testing.T) {
+	limiter := newRateLimiter(RateLimit{Rate: 0.1, Burst: 1})
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); err == nil {
+		t.Error("Expected wait to fail when the context is done")
+	}
+}
+
+// TestRateLimitersCancelReturnsTokens tests that a call giving up on the endpoint limiter
+// does not use up a token of the global limiter
+func TestRateLimitersCancelReturnsTokens(t *testing.T) {
+	limiters := newRateLimiters(&Options{
+		RateLimit:          RateLimit{Rate: 0.1, Burst: 2},
+		EndpointRateLimits: map[string]RateLimit{EndpointVASP: {Rate: 0.1, Burst: 1}},
+	})
+	ctx := context.Background()
+	if err := limiters.wait(ctx, EndpointVASP); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+
+	canceled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiters.wait(canceled, EndpointVASP); err == nil {
+		t.Fatal("Expected wait to fail when the context is done")
+	}
+
+	// Only the first call holds a global token
+	if delay := limiters.global.reserve(); delay != 0 {
+		t.Errorf("Expected a global token to be available, got a delay of %s", delay)
+	}
+}
+
+// TestRateLimiterAPICode tests that only configured rate limiting codes in successful
+// HTTP responses throttle the client
+func TestRateLimiterAPICode(t *testing.T) {
+	const throttleCode = 42900
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":%d,"msg":"too many requests"}`, throttleCode)
+	}))
+	defer server.Close()
+
+	unconfigured := NewClient("id", "secret", WithBaseURL(server.URL), WithRateLimit(100, 10)).(*client)
+	unconfigured.GetAccountBalance(context.Background())
+	if rate := unconfigured.limiters.global.rate; rate != 100 {
+		t.Errorf("Expected an unconfigured code to keep the rate, got %v", rate)
+	}
+
+	c := NewClient("id", "secret", WithBaseURL(server.URL), WithRateLimit(100, 10), WithRateLimitCodes(throttleCode)).(*client)
+	_, err := c.GetAccountBalance(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != throttleCode {
+		t.Fatalf("Expected the API error, got %v", err)
+	}
+	if rate := c.limiters.global.rate; rate != 50 {
+		t.Errorf("Expected the rate to be halved to 50, got %v", rate)
+	}
+	if tokens := c.limiters.global.tokens; tokens > 0 {
+		t.Errorf("Expected the burst to be drained, got %v tokens", tokens)
+	}
+}
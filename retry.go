@@ -0,0 +1,217 @@
+package beosin
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultRetryInitialBackoff is the default wait before the first retry
+	DefaultRetryInitialBackoff = 200 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the default upper bound for a single retry wait
+	DefaultRetryMaxBackoff = 5 * time.Second
+
+	// DefaultRetryMultiplier is the default exponential backoff multiplier
+	DefaultRetryMultiplier = 2.0
+
+	// DefaultRetryJitter is the default fraction of the backoff that is randomized
+	DefaultRetryJitter = 0.2
+
+	// DefaultRetryMaxRetryAfter is the default upper bound for a wait requested by Retry-After
+	DefaultRetryMaxRetryAfter = 30 * time.Second
+)
+
+// RetryPolicy configures automatic retries of transient failures.
+// Only idempotent requests are retried, and only when the failure is a
+// transport error or an HTTP status that indicates a temporary condition.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt (0 disables retries)
+	MaxRetries int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed exponential backoff
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor applied to the backoff after each attempt
+	Multiplier float64
+
+	// Jitter is the fraction (0-1) of the backoff that is randomized
+	Jitter float64
+
+	// MaxRetryAfter caps the wait requested by a Retry-After header
+	MaxRetryAfter time.Duration
+}
+
+// RetryEvent describes a retry that is about to happen
+type RetryEvent struct {
+	// Endpoint is the API endpoint being requested
+	Endpoint string
+
+	// Attempt is the number of the attempt that failed (starting at 1)
+	Attempt int
+
+	// Wait is the delay before the next attempt
+	Wait time.Duration
+
+	// StatusCode is the HTTP status of the failed attempt (0 for transport errors)
+	StatusCode int
+
+	// Err is the error of the failed attempt
+	Err error
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Multiplier:     DefaultRetryMultiplier,
+		Jitter:         DefaultRetryJitter,
+		MaxRetryAfter:  DefaultRetryMaxRetryAfter,
+	}
+}
+
+// applyDefaults fills unset backoff parameters with default values
+func (p *RetryPolicy) applyDefaults() {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultRetryMultiplier
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		p.Jitter = DefaultRetryJitter
+	}
+	if p.MaxRetryAfter <= 0 {
+		p.MaxRetryAfter = DefaultRetryMaxRetryAfter
+	}
+}
+
+// backoff returns the wait before the attempt following the given failed attempt
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, p.MaxRetryAfter)
+		}
+	}
+
+	wait := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		wait -= wait * p.Jitter * rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+// shouldRetry reports whether a failed attempt may be retried
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if err == nil || attempt > p.MaxRetries {
+		return false
+	}
+	if !isIdempotentMethod(method) || ctx.Err() != nil {
+		return false
+	}
+	return IsRetryable(err)
+}
+
+// isIdempotentMethod checks if requests with the given method are safe to repeat
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus checks if the HTTP status indicates a temporary condition
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransientTransportError checks if a transport error is likely to succeed on retry:
+// timeouts, failed dials and connections reset or closed by the server. DNS failures
+// are only retried when the resolver reports them as temporary.
+func isTransientTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package beosin
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// RiskLevel is a risk level returned by the API. Levels are ordered from
+// Low to Severe; empty levels rank below Low and unknown levels rank as Severe,
+// so that thresholds fail closed on levels added to the API later.
+type RiskLevel string
+
+// Risk levels returned by the API
+const (
+	RiskLevelSevere RiskLevel = "Severe"
+	RiskLevelHigh   RiskLevel = "High"
+	RiskLevelMedium RiskLevel = "Medium"
+	RiskLevelLow    RiskLevel = "Low"
+)
+
+// riskLevels lists the known levels in ascending order
+var riskLevels = []RiskLevel{RiskLevelLow, RiskLevelMedium, RiskLevelHigh, RiskLevelSevere}
+
+// ParseRiskLevel converts a string to a risk level, ignoring case and surrounding
+// whitespace. Unknown values are kept as they are.
+func ParseRiskLevel(s string) RiskLevel {
+	s = strings.TrimSpace(s)
+	for _, level := range riskLevels {
+		if strings.EqualFold(s, string(level)) {
+			return level
+		}
+	}
+	return RiskLevel(s)
+}
+
+// String returns the risk level as a string
+func (l RiskLevel) String() string {
+	return string(l)
+}
+
+// Rank returns the position of the level in the ordering: 0 for empty levels,
+// 1 for Low up to 4 for Severe, and 4 for unknown levels
+func (l RiskLevel) Rank() int {
+	if l == "" {
+		return 0
+	}
+	for i, level := range riskLevels {
+		if l == level {
+			return i + 1
+		}
+	}
+	return len(riskLevels)
+}
+
+// IsKnown checks if the level is one of the documented levels
+func (l RiskLevel) IsKnown() bool {
+	return slices.Contains(riskLevels, l)
+}
+
+// Compare returns -1, 0 or 1 depending on whether l ranks below, equal to or above other
+func (l RiskLevel) Compare(other RiskLevel) int {
+	switch a, b := l.Rank(), other.Rank(); {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast checks if the level ranks at or above min
+func (l RiskLevel) AtLeast(min RiskLevel) bool {
+	return l.Compare(min) >= 0
+}
+
+// UnmarshalJSON parses a risk level, ignoring case. Unknown values are kept
+// as they are and null decodes to an empty level.
+func (l *RiskLevel) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*l = ""
+		return nil
+	}
+	*l = ParseRiskLevel(*s)
+	return nil
+}
+
+// MaxRiskLevel returns the highest of the given levels, or an empty level if there are none.
+// Since unknown levels rank as Severe, ties are broken independently of argument order:
+// RiskLevelSevere wins over unknown levels, and the lexically smallest unknown level wins
+// over the other unknown levels.
+func MaxRiskLevel(levels ...RiskLevel) RiskLevel {
+	var max RiskLevel
+	for _, level := range levels {
+		switch c := level.Compare(max); {
+		case max == "" || c > 0:
+			max = level
+		case c == 0 && !max.IsKnown() && (level.IsKnown() || level < max):
+			max = level
+		}
+	}
+	return max
+}
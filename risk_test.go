@@ -0,0 +1,46 @@
+package beosin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestRiskLevelUnmarshal tests case-insensitive parsing and ordering of risk levels
+func TestRiskLevelUnmarshal(t *testing.T) {
+	var data V4AddressRiskData
+	body := `{"riskLevel":"HIGH","incomingLevel":"low","outgoingLevel":"Unrated","riskTagLevel":null}`
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if data.RiskLevel != RiskLevelHigh || data.IncomingLevel != RiskLevelLow {
+		t.Errorf("Expected High and Low, got %q and %q", data.RiskLevel, data.IncomingLevel)
+	}
+	if data.OutgoingLevel != "Unrated" || data.OutgoingLevel.IsKnown() || data.RiskTagLevel != "" {
+		t.Errorf("Unexpected unknown levels %q and %q", data.OutgoingLevel, data.RiskTagLevel)
+	}
+
+	if !data.RiskLevel.AtLeast(RiskLevelMedium) || data.IncomingLevel.AtLeast(RiskLevelMedium) {
+		t.Error("Unexpected AtLeast result")
+	}
+	if !data.OutgoingLevel.AtLeast(RiskLevelSevere) || data.RiskTagLevel.AtLeast(RiskLevelLow) {
+		t.Error("Expected unknown levels to rank as Severe and empty levels below Low")
+	}
+	if max := MaxRiskLevel(data.IncomingLevel, data.RiskLevel, data.RiskTagLevel); max != RiskLevelHigh {
+		t.Errorf("Expected High, got %q", max)
+	}
+	if max := MaxRiskLevel(data.RiskLevel, data.OutgoingLevel); max != "Unrated" {
+		t.Errorf("Expected the unknown level, got %q", max)
+	}
+	for _, levels := range [][]RiskLevel{{"Unrated", RiskLevelSevere, "Critical"}, {"Critical", RiskLevelSevere, "Unrated"}} {
+		if max := MaxRiskLevel(levels...); max != RiskLevelSevere {
+			t.Errorf("Expected Severe to win over unknown levels in %q, got %q", levels, max)
+		}
+	}
+	if a, b := MaxRiskLevel("Unrated", "Critical"), MaxRiskLevel("Critical", "Unrated"); a != "Critical" || b != "Critical" {
+		t.Errorf("Expected ties between unknown levels to ignore argument order, got %q and %q", a, b)
+	}
+	if level := ParseRiskLevel(" severe "); level != RiskLevelSevere || level.Rank() != 4 {
+		t.Errorf("Expected Severe, got %q", level)
+	}
+}
@@ -1,21 +1,21 @@
 package beosin
 
-import "context"
+import (
+	"context"
+	"net/url"
+)
 
 const (
-	endpointBlackScreening = "/api/v1/tag/black/screening"
+	// EndpointBlackScreening is the endpoint for black address screening
+	EndpointBlackScreening = "/api/v1/tag/black/screening"
 )
 
 // BlackAddressScreening performs black address screening
 func (c *client) BlackAddressScreening(ctx context.Context, req *BlackScreeningRequest) (*BlackScreeningResponse, error) {
-	params := buildQueryParams(map[string]string{
-		"platform": req.Platform,
-		"address":  req.Address,
+	return invoke[BlackScreeningResponse](ctx, c, OperationBlackAddressScreening, EndpointBlackScreening, req, func(req *BlackScreeningRequest) url.Values {
+		return buildQueryParams(map[string]string{
+			"platform": req.Platform,
+			"address":  req.Address,
+		})
 	})
-
-	var resp BlackScreeningResponse
-	if err := c.doRequest(ctx, endpointBlackScreening, params, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
 }
@@ -0,0 +1,172 @@
+package beosin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightCall is an in-flight request shared by concurrent identical calls
+type flightCall struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	info    CallInfo
+	waiters int
+	ctx     *flightContext
+}
+
+// flightContext is the context of a shared request. It keeps the values of the first
+// caller's context but not its cancellation, and carries the latest deadline of the
+// callers waiting for the result, so that retries stop when nobody can use them anymore.
+type flightContext struct {
+	context.Context
+
+	mu       sync.Mutex
+	done     chan struct{}
+	err      error
+	deadline time.Time
+	bounded  bool
+	timer    *time.Timer
+}
+
+// newFlightContext creates the shared context for a request started by ctx
+func newFlightContext(ctx context.Context) *flightContext {
+	c := &flightContext{Context: context.WithoutCancel(ctx), done: make(chan struct{})}
+	if deadline, ok := ctx.Deadline(); ok {
+		c.deadline, c.bounded = deadline, true
+		c.timer = time.AfterFunc(time.Until(deadline), c.expire)
+	}
+	return c
+}
+
+// Deadline returns the latest deadline of the waiting callers
+func (c *flightContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadline, c.bounded
+}
+
+// Done is closed when the shared request is canceled or its deadline passes
+func (c *flightContext) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the shared request was stopped, if it was
+func (c *flightContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// extend moves the deadline to cover a caller joining the request; a caller without
+// a deadline removes it
+func (c *flightContext) extend(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.err != nil || !c.bounded:
+	case !ok:
+		c.bounded = false
+		c.deadline = time.Time{}
+		c.timer.Stop()
+	case deadline.After(c.deadline):
+		c.deadline = deadline
+		c.timer.Reset(time.Until(deadline))
+	}
+}
+
+// expire stops the request once the current deadline has passed
+func (c *flightContext) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.bounded && !time.Now().Before(c.deadline) {
+		c.stop(context.DeadlineExceeded)
+	}
+}
+
+// cancel stops the request
+func (c *flightContext) cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stop(context.Canceled)
+}
+
+// stop records the error and closes Done; the caller must hold the lock
+func (c *flightContext) stop(err error) {
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+}
+
+// flightGroup coalesces concurrent identical requests into a single HTTP request
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// newFlightGroup creates an empty flight group
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do executes load once for all concurrent requests with the same key.
+// A caller whose context is done stops waiting without aborting the shared
+// request; the shared request is only canceled once every caller has left,
+// and runs until the latest deadline of its callers.
+func (g *flightGroup) do(ctx context.Context, r *request, load func(context.Context, *request) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	call, ok := g.calls[r.key]
+	if !ok {
+		call = &flightCall{
+			done: make(chan struct{}),
+			info: *r.info,
+			ctx:  newFlightContext(ctx),
+		}
+		g.calls[r.key] = call
+
+		shared := *r
+		shared.info = &call.info
+		go func() {
+			defer call.ctx.cancel()
+			call.body, call.err = load(call.ctx, &shared)
+
+			g.mu.Lock()
+			if g.calls[shared.key] == call {
+				delete(g.calls, shared.key)
+			}
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	} else {
+		call.ctx.extend(ctx)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		r.info.Attempts = call.info.Attempts
+		r.info.StatusCode = call.info.StatusCode
+		r.info.Shared = ok
+		return call.body, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody is interested in the result anymore; later callers start a new request
+			call.ctx.cancel()
+			if g.calls[r.key] == call {
+				delete(g.calls, r.key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,110 @@
+package beosin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRequestCoalescing tests that concurrent identical calls share one HTTP request
+// and that a caller leaving early does not abort the shared request
+func TestRequestCoalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		fmt.Fprint(w, `{"code":200,"msg":"success","data":{"score":5,"riskLevel":"Low"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithRequestCoalescing(true))
+	req := &AddressRiskRequest{ChainID: ChainETH, Address: "0x013b646fe54562a3ff6e3469fcc8c4efc2337656"}
+
+	// The first caller gives up before the response arrives
+	impatient, cancel := context.WithCancel(context.Background())
+	impatientErr := make(chan error, 1)
+	go func() {
+		_, err := client.EOAAddressRiskAssessment(impatient, req)
+		impatientErr <- err
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	var succeeded int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp, err := client.EOAAddressRiskAssessment(context.Background(), req); err == nil && resp.Data.Score == 5 {
+				atomic.AddInt32(&succeeded, 1)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-impatientErr; err == nil {
+		t.Error("Expected the canceled caller to fail")
+	}
+
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected 1 HTTP request, got %d", n)
+	}
+	if n := atomic.LoadInt32(&succeeded); n != 5 {
+		t.Errorf("Expected 5 successful callers, got %d", n)
+	}
+}
+
+// TestRequestCoalescingDeadline tests that a shared request stops retrying at the latest
+// deadline of its callers instead of running without one
+func TestRequestCoalescingDeadline(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithRequestCoalescing(true), WithMaxRetries(5))
+	req := &AddressRiskRequest{ChainID: ChainETH, Address: "0x013b646fe54562a3ff6e3469fcc8c4efc2337656"}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, timeout := range []time.Duration{100 * time.Millisecond, 300 * time.Millisecond} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			_, errs[i] = client.EOAAddressRiskAssessment(ctx, req)
+		}()
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	// The one second Retry-After does not fit before the latest deadline, so the shared
+	// request gives up with the HTTP error instead of sleeping past its callers
+	for i, err := range errs {
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Caller %d: expected the 503 error, got %v", i, err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected 1 HTTP request, got %d", n)
+	}
+}
@@ -0,0 +1,116 @@
+package beosin
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// StreamOptions configures a streaming run
+type StreamOptions struct {
+	// Concurrency is the maximum number of requests in flight (defaults to DefaultBatchConcurrency)
+	Concurrency int
+
+	// Ordered yields results in input order instead of completion order
+	Ordered bool
+}
+
+// Stream runs fn for every request pulled from reqs and yields each result with its error as it
+// becomes available. At most Concurrency requests are in flight or waiting to be yielded, so
+// inputs are only pulled as fast as the consumer processes results.
+//
+// Breaking out of the loop cancels the requests still in flight and waits for them to return.
+// When the context is done, no further inputs are pulled and the results of the requests in
+// flight are yielded with their errors before the iteration ends.
+//
+//	for result, err := range beosin.Stream(ctx, deposits, client.V4DepositTransactionAssessment, beosin.StreamOptions{}) {
+//		...
+//	}
+func Stream[Req, Resp any](ctx context.Context, reqs iter.Seq[Req], fn func(context.Context, Req) (Resp, error), opts StreamOptions) iter.Seq2[Result[Req, Resp], error] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	return func(yield func(Result[Req, Resp], error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// A slot is taken when a request starts and released when its result is yielded
+		slots := make(chan struct{}, concurrency)
+		results := make(chan Result[Req, Resp])
+
+		go func() {
+			var wg sync.WaitGroup
+			defer func() {
+				wg.Wait()
+				close(results)
+			}()
+
+			// The context is checked before every pull and launch, since a free slot may
+			// win the select against a cancellation
+			if ctx.Err() != nil {
+				return
+			}
+			index := 0
+			for req := range reqs {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				if ctx.Err() != nil {
+					return
+				}
+
+				wg.Add(1)
+				go func(index int, req Req) {
+					defer wg.Done()
+					resp, err := fn(ctx, req)
+					results <- Result[Req, Resp]{Index: index, Request: req, Response: resp, Err: err}
+				}(index, req)
+				index++
+				if ctx.Err() != nil {
+					return
+				}
+			}
+		}()
+
+		// stop cancels the remaining work and waits for all goroutines to finish
+		stop := func() {
+			cancel()
+			for range results {
+			}
+		}
+
+		if !opts.Ordered {
+			for result := range results {
+				<-slots
+				if !yield(result, result.Err) {
+					stop()
+					return
+				}
+			}
+			return
+		}
+
+		pending := make(map[int]Result[Req, Resp])
+		next := 0
+		for result := range results {
+			pending[result.Index] = result
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				<-slots
+				if !yield(ready, ready.Err) {
+					stop()
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package beosin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStreamOrdered tests that ordered streams yield results in input order
+func TestStreamOrdered(t *testing.T) {
+	reqs := []*DepositRequest{{Hash: "0x1"}, {Hash: "0x2"}, {Hash: "0x3"}, {Hash: "0x4"}}
+	assess := func(ctx context.Context, req *DepositRequest) (*TransactionRiskResponse, error) {
+		// Earlier requests finish later
+		time.Sleep(time.Duration(len(reqs)-len(req.Hash)) * time.Millisecond)
+		if req.Hash == "0x3" {
+			return nil, fmt.Errorf("failed")
+		}
+		return &TransactionRiskResponse{Data: &TransactionRiskData{Score: 1}}, nil
+	}
+
+	var indexes []int
+	var failed int
+	for result, err := range Stream(context.Background(), slices.Values(reqs), assess, StreamOptions{Concurrency: 3, Ordered: true}) {
+		indexes = append(indexes, result.Index)
+		if err != nil {
+			failed++
+		}
+	}
+
+	if !slices.Equal(indexes, []int{0, 1, 2, 3}) {
+		t.Errorf("Expected results in input order, got %v", indexes)
+	}
+	if failed != 1 {
+		t.Errorf("Expected 1 failure, got %d", failed)
+	}
+}
+
+// TestStreamBreak tests that breaking out of the loop stops pulling inputs and cancels requests
+func TestStreamBreak(t *testing.T) {
+	var pulled, canceled int32
+	inputs := func(yield func(*AddressRiskRequest) bool) {
+		for i := 0; ; i++ {
+			atomic.AddInt32(&pulled, 1)
+			if !yield(&AddressRiskRequest{Address: fmt.Sprint(i)}) {
+				return
+			}
+		}
+	}
+	assess := func(ctx context.Context, req *AddressRiskRequest) (*AddressRiskResponse, error) {
+		if req.Address == "0" {
+			return &AddressRiskResponse{}, nil
+		}
+		<-ctx.Done()
+		atomic.AddInt32(&canceled, 1)
+		return nil, ctx.Err()
+	}
+
+	for range Stream(context.Background(), inputs, assess, StreamOptions{Concurrency: 2}) {
+		break
+	}
+
+	// One slot is freed by the yielded result, plus one input may be pulled while waiting for a slot
+	if n := atomic.LoadInt32(&pulled); n > 4 {
+		t.Errorf("Expected at most 4 inputs to be pulled, got %d", n)
+	}
+	if n := atomic.LoadInt32(&canceled); n == 0 {
+		t.Error("Expected in-flight requests to be canceled before the stream returned")
+	}
+}
+
+// TestStreamNoCallsAfterBreak tests that an input pulled while the consumer breaks out of
+// the loop is not sent, even though a slot is free
+func TestStreamNoCallsAfterBreak(t *testing.T) {
+	for run := 0; run < 20; run++ {
+		gate := make(chan struct{})
+		var pulled, calls int32
+		inputs := func(yield func(*AddressRiskRequest) bool) {
+			for i := 0; ; i++ {
+				if i == 1 {
+					<-gate
+				}
+				atomic.AddInt32(&pulled, 1)
+				if !yield(&AddressRiskRequest{Address: fmt.Sprint(i)}) {
+					return
+				}
+			}
+		}
+		assess := func(ctx context.Context, req *AddressRiskRequest) (*AddressRiskResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return &AddressRiskResponse{}, nil
+		}
+
+		for range Stream(context.Background(), inputs, assess, StreamOptions{Concurrency: 2}) {
+			// The pending pull completes only after the stream has been stopped
+			time.AfterFunc(5*time.Millisecond, func() { close(gate) })
+			break
+		}
+
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("Expected 1 call, got %d after breaking out of the loop", n)
+		}
+		if n := atomic.LoadInt32(&pulled); n > 2 {
+			t.Fatalf("Expected no inputs to be pulled after the break, got %d", n)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package beosin
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// ValidateTxHash checks a transaction hash or signature against the format of the chain and
+// returns its canonical form. The chain may be given by ID or alias; hashes on chains missing
+// from the registry are returned unchanged.
+func ValidateTxHash(chainID, hash string) (string, error) {
+	chain, ok := LookupChain(chainID)
+	if !ok {
+		return hash, nil
+	}
+	normalized, reason := normalizeTxHash(chain, strings.TrimSpace(hash))
+	if reason != "" {
+		return "", &ValidationError{Field: "hash", Value: hash, Reason: reason}
+	}
+	return normalized, nil
+}
+
+// normalizeTxHash returns the canonical form of a transaction hash, or the reason it is invalid
+func normalizeTxHash(chain Chain, hash string) (string, string) {
+	if hash == "" {
+		return "", "must not be empty"
+	}
+
+	switch chain.Family {
+	case FamilyEVM, FamilyAptos:
+		digits, _ := strings.CutPrefix(strings.ToLower(hash), "0x")
+		if len(digits) != 64 || !isHex(digits) {
+			return "", "must be 0x followed by 64 hex digits"
+		}
+		return "0x" + digits, ""
+	case FamilyUTXO, FamilyTron:
+		if len(hash) != 64 || !isHex(hash) {
+			return "", "must be 64 hex digits"
+		}
+		return strings.ToLower(hash), ""
+	case FamilyXRP:
+		if len(hash) != 64 || !isHex(hash) {
+			return "", "must be 64 hex digits"
+		}
+		return strings.ToUpper(hash), ""
+	case FamilySolana:
+		if data, ok := bitcoinAlphabet.decode(hash); !ok || len(data) != 64 {
+			return "", "must be a base58 encoded 64-byte signature"
+		}
+		return hash, ""
+	case FamilySui:
+		if data, ok := bitcoinAlphabet.decode(hash); !ok || len(data) != 32 {
+			return "", "must be a base58 encoded 32-byte digest"
+		}
+		return hash, ""
+	case FamilyTON:
+		if len(hash) == 64 && isHex(hash) {
+			return strings.ToLower(hash), ""
+		}
+		encoding := base64.StdEncoding
+		if strings.ContainsAny(hash, "-_") {
+			encoding = base64.URLEncoding
+		}
+		if data, err := encoding.DecodeString(hash); err != nil || len(data) != 32 {
+			return "", "must be 64 hex digits or a base64 encoded 32-byte hash"
+		}
+		return hash, ""
+	}
+	return hash, ""
+}
@@ -0,0 +1,76 @@
+package beosin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestValidateTxHash tests transaction hash validation and normalization for every chain family
+func TestValidateTxHash(t *testing.T) {
+	hexHash := "5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	suiDigest := bitcoinAlphabet.encode(bytes.Repeat([]byte{0xab}, 32))
+	solanaSignature := "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"
+
+	tests := []struct {
+		chain string
+		hash  string
+		want  string
+		valid bool
+	}{
+		{ChainETH, "0x" + hexHash, "0x" + hexHash, true},
+		{ChainETH, "0X5C504ED432CB51138BCF09AA5E8A410DD4A1E204EF84BFED1BE16DFBA1B22060", "0x" + hexHash, true},
+		{ChainETH, hexHash, "0x" + hexHash, true},
+		{ChainETH, "0x" + hexHash[:62], "", false},
+		{ChainBTC, hexHash, hexHash, true},
+		{ChainBTC, "0x" + hexHash, "", false},
+		{ChainTron, " " + hexHash + " ", hexHash, true},
+		{ChainXRP, hexHash, "5C504ED432CB51138BCF09AA5E8A410DD4A1E204EF84BFED1BE16DFBA1B22060", true},
+		{ChainAptos, "0x" + hexHash, "0x" + hexHash, true},
+		{ChainSolana, solanaSignature, solanaSignature, true},
+		{ChainSolana, hexHash, "", false},
+		{ChainSui, suiDigest, suiDigest, true},
+		{ChainSui, "0x" + hexHash, "", false},
+		{ChainTON, hexHash, hexHash, true},
+		{ChainTON, "XFBO1DLLUROLzwmqXopBDdSh4gTvhL/tG+Ft+6GyIGA=", "XFBO1DLLUROLzwmqXopBDdSh4gTvhL/tG+Ft+6GyIGA=", true},
+		{ChainTON, "not-a-hash", "", false},
+		{ChainETH, "", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := ValidateTxHash(tt.chain, tt.hash)
+		if tt.valid && (err != nil || got != tt.want) {
+			t.Errorf("ValidateTxHash(%s, %s) = %q, %v; want %q", tt.chain, tt.hash, got, err, tt.want)
+		}
+		if !tt.valid && !errors.Is(err, ErrTxHashInvalid) {
+			t.Errorf("ValidateTxHash(%s, %s) = %q, %v; want a hash error", tt.chain, tt.hash, got, err)
+		}
+	}
+}
+
+// TestTxHashPreflight tests that the assessment methods reject malformed hashes without an HTTP call
+func TestTxHashPreflight(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"code":200,"msg":"success","data":{"score":0,"riskLevel":"Low"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret", WithBaseURL(server.URL), WithRequestValidation(true))
+	_, err := client.V4WithdrawalTransactionAssessment(context.Background(), &WithdrawalRequest{ChainID: ChainBTC, Hash: "0x1234"})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "hash" || validationErr.Reason == "" {
+		t.Fatalf("Expected a hash validation error, got %v", err)
+	}
+	if !errors.Is(err, ErrTxHashInvalid) {
+		t.Error("Expected the validation error to match ErrTxHashInvalid")
+	}
+	if calls != 0 {
+		t.Errorf("Expected no HTTP calls, got %d", calls)
+	}
+}
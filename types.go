@@ -41,14 +41,6 @@ const (
 	ChainConfluxESpace = "1030"
 )
 
-// Risk levels returned by the API
-const (
-	RiskLevelSevere = "Severe"
-	RiskLevelHigh   = "High"
-	RiskLevelMedium = "Medium"
-	RiskLevelLow    = "Low"
-)
-
 // Exposure types for V4 API
 const (
 	ExposureDirect   = "Direct"
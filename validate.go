@@ -0,0 +1,158 @@
+package beosin
+
+import "fmt"
+
+// ValidationError is returned when a request fails local validation before it is sent.
+// It matches the sentinel of the API error code the API would have returned, e.g.
+// errors.Is(err, ErrAddressInvalid).
+type ValidationError struct {
+	// Field is the name of the invalid request parameter (e.g. "address")
+	Field string
+
+	// Value is the rejected value. Error masks it; log it only where addresses
+	// and hashes may be recorded.
+	Value string
+
+	// Reason describes why the value was rejected
+	Reason string
+}
+
+// Error implements the error interface. The value is masked like in debug logs.
+func (e *ValidationError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("beosin: invalid %s: %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("beosin: invalid %s %q: %s", e.Field, maskValue(e.Value), e.Reason)
+}
+
+// Is reports whether target is the sentinel API error matching the invalid field
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	switch e.Field {
+	case "address":
+		return t.Code == ErrCodeAddressError
+	case "hash":
+		return t.Code == ErrCodeTxHashError
+	}
+	return t.Code == ErrCodeParameterError
+}
+
+// requestNormalizer is implemented by requests that can be validated and normalized locally
+type requestNormalizer interface {
+	Normalize() error
+}
+
+// normalizeAddressParams validates the chain and address of a request and rewrites both
+// in their canonical form
+func normalizeAddressParams(chainField string, chainID, address *string) error {
+	if *chainID == "" {
+		return &ValidationError{Field: chainField, Reason: "must not be empty"}
+	}
+	normalized, err := ValidateAddress(*chainID, *address)
+	if err != nil {
+		return err
+	}
+	*chainID = canonicalChain(chainField, *chainID)
+	*address = normalized
+	return nil
+}
+
+// normalizeHashParams validates the chain and transaction hash of a request and rewrites both
+// in their canonical form
+func normalizeHashParams(chainID, hash *string) error {
+	if *chainID == "" {
+		return &ValidationError{Field: "chainId", Reason: "must not be empty"}
+	}
+	normalized, err := ValidateTxHash(*chainID, *hash)
+	if err != nil {
+		return err
+	}
+	*chainID = canonicalChain("chainId", *chainID)
+	*hash = normalized
+	return nil
+}
+
+// canonicalChain resolves a chain alias to the chain ID, or to the screening platform
+// for the platform field. Values without a canonical form are kept as they are.
+func canonicalChain(field, alias string) string {
+	chain, ok := LookupChain(alias)
+	switch {
+	case !ok:
+		return alias
+	case field == "platform":
+		if chain.Platform == "" {
+			return alias
+		}
+		return chain.Platform
+	}
+	return chain.ID
+}
+
+// Validate checks the transaction hash against the format of the chain
+func (r *DepositRequest) Validate() error {
+	copied := *r
+	return copied.Normalize()
+}
+
+// Normalize validates the request and rewrites the chain and transaction hash in their canonical form
+func (r *DepositRequest) Normalize() error {
+	return normalizeHashParams(&r.ChainID, &r.Hash)
+}
+
+// Validate checks the transaction hash against the format of the chain
+func (r *WithdrawalRequest) Validate() error {
+	copied := *r
+	return copied.Normalize()
+}
+
+// Normalize validates the request and rewrites the chain and transaction hash in their canonical form
+func (r *WithdrawalRequest) Normalize() error {
+	return normalizeHashParams(&r.ChainID, &r.Hash)
+}
+
+// Validate checks the address against the format of the chain
+func (r *AddressRiskRequest) Validate() error {
+	copied := *r
+	return copied.Normalize()
+}
+
+// Normalize validates the request and rewrites the chain and address in their canonical form
+func (r *AddressRiskRequest) Normalize() error {
+	return normalizeAddressParams("chainId", &r.ChainID, &r.Address)
+}
+
+// Validate checks the address against the format of the chain
+func (r *MaliciousAddressRequest) Validate() error {
+	copied := *r
+	return copied.Normalize()
+}
+
+// Normalize validates the request and rewrites the chain and address in their canonical form
+func (r *MaliciousAddressRequest) Normalize() error {
+	return normalizeAddressParams("chainId", &r.ChainID, &r.Address)
+}
+
+// Validate checks the address against the format of the chain
+func (r *VASPRequest) Validate() error {
+	copied := *r
+	return copied.Normalize()
+}
+
+// Normalize validates the request and rewrites the chain and address in their canonical form
+func (r *VASPRequest) Normalize() error {
+	return normalizeAddressParams("chainId", &r.ChainID, &r.Address)
+}
+
+// Validate checks the address against the format of the platform
+func (r *BlackScreeningRequest) Validate() error {
+	copied := *r
+	return copied.Normalize()
+}
+
+// Normalize validates the request and rewrites the chain and address in their canonical form
+func (r *BlackScreeningRequest) Normalize() error {
+	return normalizeAddressParams("platform", &r.Platform, &r.Address)
+}